@@ -63,6 +63,21 @@ func dataSourceApplicationInsights() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"sampling_percentage": {
+				Type:     pluginsdk.TypeFloat,
+				Computed: true,
+			},
+
+			"disable_ip_masking": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
+			"force_customer_storage_for_profiler": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
 			"workspace_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -110,6 +125,14 @@ func dataSourceArmApplicationInsightsRead(d *pluginsdk.ResourceData, meta interf
 		}
 		d.Set("retention_in_days", retentionInDays)
 
+		samplingPercentage := 100.0
+		if props.SamplingPercentage != nil {
+			samplingPercentage = *props.SamplingPercentage
+		}
+		d.Set("sampling_percentage", samplingPercentage)
+		d.Set("disable_ip_masking", props.DisableIPMasking != nil && *props.DisableIPMasking)
+		d.Set("force_customer_storage_for_profiler", props.ForceCustomerStorageForProfiler != nil && *props.ForceCustomerStorageForProfiler)
+
 		workspaceId := ""
 		if props.WorkspaceResourceID != nil {
 			workspaceId = *props.WorkspaceResourceID
@@ -22,6 +22,9 @@ func TestAccApplicationInsightsDataSource_basic(t *testing.T) {
 				check.That(data.ResourceName).Key("location").Exists(),
 				check.That(data.ResourceName).Key("workspace_id").Exists(),
 				check.That(data.ResourceName).Key("application_type").HasValue("other"),
+				check.That(data.ResourceName).Key("sampling_percentage").HasValue("50"),
+				check.That(data.ResourceName).Key("disable_ip_masking").HasValue("true"),
+				check.That(data.ResourceName).Key("force_customer_storage_for_profiler").HasValue("true"),
 				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
 				check.That(data.ResourceName).Key("tags.foo").HasValue("bar"),
 			),
@@ -54,6 +57,11 @@ resource "azurerm_application_insights" "test" {
   resource_group_name = azurerm_resource_group.test.name
   application_type    = "other"
   workspace_id        = azurerm_log_analytics_workspace.test.id
+
+  sampling_percentage                 = 50
+  disable_ip_masking                  = true
+  force_customer_storage_for_profiler = true
+
   tags = {
     "foo" = "bar"
   }
@@ -1,6 +1,7 @@
 package applicationinsights
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -48,6 +49,8 @@ func resourceApplicationInsights() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceApplicationInsightsCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -169,6 +172,42 @@ func resourceApplicationInsights() *pluginsdk.Resource {
 	}
 }
 
+func resourceApplicationInsightsCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// the raw config is used since the workspace may not be known until apply
+	workspaceId := d.GetRawConfig().GetAttr("workspace_id")
+	if oldWorkspaceId, _ := d.GetChange("workspace_id"); oldWorkspaceId.(string) != "" {
+		if workspaceId.IsKnown() && workspaceId.IsNull() {
+			return fmt.Errorf("`workspace_id` can not be removed after set")
+		}
+		return nil
+	}
+	if workspaceId.IsNull() {
+		return nil
+	}
+
+	// migrating a classic Application Insights to workspace-based stops Continuous Export, which isn't managed by
+	// Terraform and so would otherwise break silently
+	id, err := parse.ComponentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*clients.Client).AppInsights.ExportConfigurationsClient
+	exports, err := client.List(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("listing Continuous Export configurations for %s: %+v", *id, err)
+	}
+	if exports.Value != nil && len(*exports.Value) > 0 {
+		return fmt.Errorf("%s has %d Continuous Export configuration(s) which aren't supported by workspace-based Application Insights - these must be migrated to Diagnostic Settings and removed before `workspace_id` can be set, see https://learn.microsoft.com/azure/azure-monitor/app/convert-classic-resource", *id, len(*exports.Value))
+	}
+
+	return nil
+}
+
 func resourceApplicationInsightsCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).AppInsights.ComponentsClient
 	ruleClient := meta.(*clients.Client).Monitor.SmartDetectorAlertRulesClient
@@ -565,8 +565,12 @@ func flattenApplicationInsightsStandardWebTestRequestHeaders(input *[]webtests.H
 
 	for _, v := range headers {
 		header := make(map[string]string, 2)
-		header["name"] = *v.Key
-		header["value"] = *v.Value
+		if v.Key != nil {
+			header["name"] = *v.Key
+		}
+		if v.Value != nil {
+			header["value"] = *v.Value
+		}
 		result = append(result, header)
 	}
 
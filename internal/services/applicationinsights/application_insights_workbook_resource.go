@@ -25,6 +25,7 @@ type ApplicationInsightsWorkbookModel struct {
 	DisplayName        string            `tfschema:"display_name"`
 	Location           string            `tfschema:"location"`
 	DataJson           string            `tfschema:"data_json"`
+	Kind               string            `tfschema:"kind"`
 	SourceId           string            `tfschema:"source_id"`
 	StorageContainerId string            `tfschema:"storage_container_id"`
 	Tags               map[string]string `tfschema:"tags"`
@@ -119,7 +120,12 @@ func (r ApplicationInsightsWorkbookResource) Arguments() map[string]*pluginsdk.S
 }
 
 func (r ApplicationInsightsWorkbookResource) Attributes() map[string]*pluginsdk.Schema {
-	return map[string]*pluginsdk.Schema{}
+	return map[string]*pluginsdk.Schema{
+		"kind": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
 }
 
 func (r ApplicationInsightsWorkbookResource) Create() sdk.ResourceFunc {
@@ -279,6 +285,10 @@ func (r ApplicationInsightsWorkbookResource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("setting `identity`: %+v", err)
 			}
 
+			if model.Kind != nil {
+				state.Kind = string(*model.Kind)
+			}
+
 			if properties := model.Properties; properties != nil {
 				state.Category = properties.Category
 
@@ -25,6 +25,8 @@ func TestAccApplicationInsightsWorkbook_basic(t *testing.T) {
 			Config: r.basic(data, data.RandomInteger),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("kind").HasValue("shared"),
+				check.That(data.ResourceName).Key("category").HasValue("workbook"),
 			),
 		},
 		data.ImportStep(),
@@ -12,15 +12,16 @@ import (
 )
 
 type Client struct {
-	AnalyticsItemsClient     *insights.AnalyticsItemsClient
-	APIKeysClient            *insights.APIKeysClient
-	ComponentsClient         *insights.ComponentsClient
-	WebTestsClient           *azuresdkhacks.WebTestsClient
-	StandardWebTestsClient   *webtests.WebTestsAPIsClient
-	BillingClient            *insights.ComponentCurrentBillingFeaturesClient
-	SmartDetectionRuleClient *insights.ProactiveDetectionConfigurationsClient
-	WorkbookClient           *workbooks.WorkbooksAPIsClient
-	WorkbookTemplateClient   *workbooktemplates.WorkbookTemplatesAPIsClient
+	AnalyticsItemsClient       *insights.AnalyticsItemsClient
+	APIKeysClient              *insights.APIKeysClient
+	ComponentsClient           *insights.ComponentsClient
+	ExportConfigurationsClient *insights.ExportConfigurationsClient
+	WebTestsClient             *azuresdkhacks.WebTestsClient
+	StandardWebTestsClient     *webtests.WebTestsAPIsClient
+	BillingClient              *insights.ComponentCurrentBillingFeaturesClient
+	SmartDetectionRuleClient   *insights.ProactiveDetectionConfigurationsClient
+	WorkbookClient             *workbooks.WorkbooksAPIsClient
+	WorkbookTemplateClient     *workbooktemplates.WorkbookTemplatesAPIsClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -33,6 +34,9 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	componentsClient := insights.NewComponentsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&componentsClient.Client, o.ResourceManagerAuthorizer)
 
+	exportConfigurationsClient := insights.NewExportConfigurationsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&exportConfigurationsClient.Client, o.ResourceManagerAuthorizer)
+
 	webTestsClient := insights.NewWebTestsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&webTestsClient.Client, o.ResourceManagerAuthorizer)
 	webTestsWorkaroundClient := azuresdkhacks.NewWebTestsClient(webTestsClient)
@@ -62,14 +66,15 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(workbookTemplateClient.Client, o.Authorizers.ResourceManager)
 
 	return &Client{
-		AnalyticsItemsClient:     &analyticsItemsClient,
-		APIKeysClient:            &apiKeysClient,
-		ComponentsClient:         &componentsClient,
-		WebTestsClient:           &webTestsWorkaroundClient,
-		BillingClient:            &billingClient,
-		SmartDetectionRuleClient: &smartDetectionRuleClient,
-		WorkbookClient:           workbookClient,
-		WorkbookTemplateClient:   workbookTemplateClient,
-		StandardWebTestsClient:   standardWebTestsClient,
+		AnalyticsItemsClient:       &analyticsItemsClient,
+		APIKeysClient:              &apiKeysClient,
+		ComponentsClient:           &componentsClient,
+		ExportConfigurationsClient: &exportConfigurationsClient,
+		WebTestsClient:             &webTestsWorkaroundClient,
+		BillingClient:              &billingClient,
+		SmartDetectionRuleClient:   &smartDetectionRuleClient,
+		WorkbookClient:             workbookClient,
+		WorkbookTemplateClient:     workbookTemplateClient,
+		StandardWebTestsClient:     standardWebTestsClient,
 	}, nil
 }
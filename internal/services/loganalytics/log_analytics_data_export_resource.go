@@ -237,14 +237,14 @@ func flattenDataExportDestination(input *dataexport.Destination) (string, error)
 	var resourceID string
 	if input.ResourceId != "" {
 		resourceID = input.ResourceId
-		if *input.Type == dataexport.TypeEventHub {
+		if input.Type != nil && *input.Type == dataexport.TypeEventHub {
 			if input.MetaData != nil && input.MetaData.EventHubName != nil {
 				eventhubName := *input.MetaData.EventHubName
 				eventhubNamespaceId, err := eventhubs.ParseNamespaceIDInsensitively(resourceID)
-				eventhubId := eventhubs.NewEventhubID(eventhubNamespaceId.SubscriptionId, eventhubNamespaceId.ResourceGroupName, eventhubNamespaceId.NamespaceName, eventhubName)
 				if err != nil {
-					return "", fmt.Errorf("parsing destination eventhub namespace ID error")
+					return "", fmt.Errorf("parsing destination eventhub namespace ID error: %+v", err)
 				}
+				eventhubId := eventhubs.NewEventhubID(eventhubNamespaceId.SubscriptionId, eventhubNamespaceId.ResourceGroupName, eventhubNamespaceId.NamespaceName, eventhubName)
 				resourceID = eventhubId.ID()
 			}
 		}
@@ -3,6 +3,7 @@ package loganalytics_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/linkedstorageaccounts"
@@ -100,6 +101,18 @@ func TestAcclogAnalyticsLinkedStorageAccount_ingestion(t *testing.T) {
 	})
 }
 
+func TestAcclogAnalyticsLinkedStorageAccount_invalidDataSourceType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_linked_storage_account", "test")
+	r := LogAnalyticsLinkedStorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.dataSourceType(data, "CustomLog"),
+			ExpectError: regexp.MustCompile("expected data_source_type to be one of"),
+		},
+	})
+}
+
 func (t LogAnalyticsLinkedStorageAccountResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := linkedstorageaccounts.ParseDataSourceTypeID(state.ID)
 	if err != nil {
@@ -201,3 +214,16 @@ resource "azurerm_log_analytics_linked_storage_account" "test" {
 }
 `, r.template(data))
 }
+
+func (r LogAnalyticsLinkedStorageAccountResource) dataSourceType(data acceptance.TestData, dataSourceType string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_linked_storage_account" "test" {
+  data_source_type      = "%s"
+  resource_group_name   = azurerm_resource_group.test.name
+  workspace_resource_id = azurerm_log_analytics_workspace.test.id
+  storage_account_ids   = [azurerm_storage_account.test.id]
+}
+`, r.template(data), dataSourceType)
+}
@@ -9,7 +9,8 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
-	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
+	sharedKeyWorkspaces "github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/workspaces"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
@@ -54,6 +55,11 @@ func dataSourceLogAnalyticsWorkspace() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"local_authentication_disabled": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
 			"primary_shared_key": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -72,7 +78,8 @@ func dataSourceLogAnalyticsWorkspace() *pluginsdk.Resource {
 }
 
 func dataSourceLogAnalyticsWorkspaceRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).LogAnalytics.SharedKeyWorkspacesClient
+	client := meta.(*clients.Client).LogAnalytics.WorkspaceClient
+	sharedKeyClient := meta.(*clients.Client).LogAnalytics.SharedKeyWorkspacesClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
@@ -121,6 +128,12 @@ func dataSourceLogAnalyticsWorkspaceRead(d *pluginsdk.ResourceData, meta interfa
 			} else {
 				d.Set("daily_quota_gb", utils.Float(-1))
 			}
+
+			disableLocalAuth := false
+			if features := props.Features; features != nil && features.DisableLocalAuth != nil {
+				disableLocalAuth = *features.DisableLocalAuth
+			}
+			d.Set("local_authentication_disabled", disableLocalAuth)
 		}
 
 		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
@@ -128,7 +141,8 @@ func dataSourceLogAnalyticsWorkspaceRead(d *pluginsdk.ResourceData, meta interfa
 		}
 	}
 
-	sharedKeysResp, err := client.SharedKeysGetSharedKeys(ctx, id)
+	sharedKeyId := sharedKeyWorkspaces.NewWorkspaceID(id.SubscriptionId, id.ResourceGroupName, id.WorkspaceName)
+	sharedKeysResp, err := sharedKeyClient.SharedKeysGetSharedKeys(ctx, sharedKeyId)
 	if err != nil {
 		log.Printf("[ERROR] Unable to List Shared keys for Log Analytics workspaces %s: %+v", name, err)
 	} else {
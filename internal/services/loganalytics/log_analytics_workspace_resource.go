@@ -142,6 +142,11 @@ func resourceLogAnalyticsWorkspace() *pluginsdk.Resource {
 				ValidateFunc:     validation.FloatAtLeast(-1.0),
 			},
 
+			"daily_quota_reset_time": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"workspace_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -416,6 +421,12 @@ func resourceLogAnalyticsWorkspaceRead(d *pluginsdk.ResourceData, meta interface
 				d.Set("daily_quota_gb", utils.Float(-1))
 			}
 
+			dailyQuotaResetTime := ""
+			if props.WorkspaceCapping != nil && props.WorkspaceCapping.QuotaNextResetTime != nil {
+				dailyQuotaResetTime = *props.WorkspaceCapping.QuotaNextResetTime
+			}
+			d.Set("daily_quota_reset_time", dailyQuotaResetTime)
+
 			allowResourceOnlyPermissions := true
 			disableLocalAuth := false
 			if features := props.Features; features != nil {
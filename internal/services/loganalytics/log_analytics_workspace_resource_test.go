@@ -142,6 +142,7 @@ func TestAccLogAnalyticsWorkspace_withVolumeCap(t *testing.T) {
 			Config: r.withVolumeCap(data, 4.5),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("daily_quota_reset_time").IsNotEmpty(),
 			),
 		},
 		data.ImportStep(),
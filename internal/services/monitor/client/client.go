@@ -1,6 +1,8 @@
 package client
 
 import (
+	"sync"
+
 	"github.com/Azure/azure-sdk-for-go/services/aad/mgmt/2017-04-01/aad"                                           // nolint: staticcheck
 	"github.com/Azure/azure-sdk-for-go/services/preview/alertsmanagement/mgmt/2019-06-01-preview/alertsmanagement" // nolint: staticcheck
 	classic "github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-07-01-preview/insights"          // nolint: staticcheck
@@ -26,7 +28,8 @@ import (
 
 type Client struct {
 	// AAD
-	AADDiagnosticSettingsClient *aad.DiagnosticSettingsClient
+	AADDiagnosticSettingsClient         *aad.DiagnosticSettingsClient
+	AADDiagnosticSettingsCategoryClient *aad.DiagnosticSettingsCategoryClient
 
 	// Autoscale Settings
 	AutoscaleSettingsClient *autoscalesettings.AutoScaleSettingsClient
@@ -54,12 +57,26 @@ type Client struct {
 	ScheduledQueryRulesClient            *scheduledqueryrules2018.ScheduledQueryRulesClient
 	ScheduledQueryRulesV2Client          *scheduledqueryrules.ScheduledQueryRulesClient
 	WorkspacesClient                     *azuremonitorworkspaces.AzureMonitorWorkspacesClient
+
+	// diagnosticSettingsCategories caches the Diagnostic Settings Categories of each Resource for the lifetime of this
+	// client, see DiagnosticSettingsCategoriesForResource
+	diagnosticSettingsCategories sync.Map
+
+	// existingResources caches the IDs of resources known to exist for the lifetime of this client, see resourceExists
+	existingResources sync.Map
+
+	// dataCollectionRuleAssociations caches the associations of each Data Collection Rule for the lifetime of this
+	// client, see DataCollectionRuleAssociationsForRule
+	dataCollectionRuleAssociations dataCollectionRuleAssociationsCache
 }
 
 func NewClient(o *common.ClientOptions) *Client {
 	AADDiagnosticSettingsClient := aad.NewDiagnosticSettingsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&AADDiagnosticSettingsClient.Client, o.ResourceManagerAuthorizer)
 
+	AADDiagnosticSettingsCategoryClient := aad.NewDiagnosticSettingsCategoryClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&AADDiagnosticSettingsCategoryClient.Client, o.ResourceManagerAuthorizer)
+
 	AutoscaleSettingsClient := autoscalesettings.NewAutoScaleSettingsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&AutoscaleSettingsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -125,6 +142,7 @@ func NewClient(o *common.ClientOptions) *Client {
 
 	return &Client{
 		AADDiagnosticSettingsClient:          &AADDiagnosticSettingsClient,
+		AADDiagnosticSettingsCategoryClient:  &AADDiagnosticSettingsCategoryClient,
 		AutoscaleSettingsClient:              &AutoscaleSettingsClient,
 		ActionRulesClient:                    &ActionRulesClient,
 		SmartDetectorAlertRulesClient:        &SmartDetectorAlertRulesClient,
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	diagnosticCategoryClient "github.com/hashicorp/go-azure-sdk/resource-manager/insights/2021-05-01-preview/diagnosticsettingscategories"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionrules"
+)
+
+type diagnosticSettingsCategoriesForResource struct {
+	once       sync.Once
+	categories []diagnosticCategoryClient.DiagnosticSettingsCategoryResource
+	err        error
+}
+
+// DiagnosticSettingsCategoriesForResource returns the Diagnostic Settings Categories available for the specified
+// Resource. These are listed once per Resource for the lifetime of the client (a single Terraform operation) to avoid
+// listing them repeatedly when many data sources or diagnostic settings reference the same Resource. Failures aren't
+// cached.
+func (c *Client) DiagnosticSettingsCategoriesForResource(ctx context.Context, resourceId commonids.ScopeId) (*[]diagnosticCategoryClient.DiagnosticSettingsCategoryResource, error) {
+	cacheKey := strings.ToLower(resourceId.Scope)
+	v, _ := c.diagnosticSettingsCategories.LoadOrStore(cacheKey, &diagnosticSettingsCategoriesForResource{})
+	entry := v.(*diagnosticSettingsCategoriesForResource)
+
+	entry.once.Do(func() {
+		entry.categories, entry.err = c.listDiagnosticSettingsCategories(ctx, resourceId)
+	})
+	if entry.err != nil {
+		c.diagnosticSettingsCategories.CompareAndDelete(cacheKey, entry)
+		return nil, entry.err
+	}
+
+	return &entry.categories, nil
+}
+
+func (c *Client) listDiagnosticSettingsCategories(ctx context.Context, resourceId commonids.ScopeId) ([]diagnosticCategoryClient.DiagnosticSettingsCategoryResource, error) {
+	resp, err := c.DiagnosticSettingsCategoryClient.DiagnosticSettingsCategoryList(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Model == nil || resp.Model.Value == nil {
+		return nil, fmt.Errorf("`model.Value` was nil")
+	}
+
+	return *resp.Model.Value, nil
+}
+
+// DataCollectionRuleExists returns whether the specified Data Collection Rule exists. Only Data Collection Rules which
+// exist are cached - and only for the lifetime of the client (a single Terraform operation) - so that the many Data
+// Collection Rule Associations which commonly reference a single Data Collection Rule don't each retrieve it, whilst a
+// Data Collection Rule which doesn't exist yet is looked up again.
+func (c *Client) DataCollectionRuleExists(ctx context.Context, id datacollectionrules.DataCollectionRuleId) (bool, error) {
+	return c.resourceExists(id.ID(), func() (bool, error) {
+		resp, err := c.DataCollectionRulesClient.Get(ctx, id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// DataCollectionEndpointExists returns whether the specified Data Collection Endpoint exists, caching it in the same
+// manner as DataCollectionRuleExists.
+func (c *Client) DataCollectionEndpointExists(ctx context.Context, id datacollectionendpoints.DataCollectionEndpointId) (bool, error) {
+	return c.resourceExists(id.ID(), func() (bool, error) {
+		resp, err := c.DataCollectionEndpointsClient.Get(ctx, id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+func (c *Client) resourceExists(resourceId string, lookup func() (bool, error)) (bool, error) {
+	cacheKey := strings.ToLower(resourceId)
+	if _, ok := c.existingResources.Load(cacheKey); ok {
+		return true, nil
+	}
+
+	exists, err := lookup()
+	if err != nil {
+		return false, err
+	}
+
+	if exists {
+		c.existingResources.Store(cacheKey, struct{}{})
+	}
+
+	return exists, nil
+}
+
+// dataCollectionRuleAssociationsCache caches the associations of each Data Collection Rule, see
+// DataCollectionRuleAssociationsForRule
+type dataCollectionRuleAssociationsCache struct {
+	entries sync.Map
+}
+
+type dataCollectionRuleAssociationsCacheEntry struct {
+	// lock ensures concurrent callers list the associations once - each caller lists them using its own context, so a
+	// caller which is cancelled whilst listing doesn't fail the callers waiting on it
+	lock   sync.Mutex
+	loaded bool
+	items  []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource
+}
+
+type listDataCollectionRuleAssociationsFunc func(ctx context.Context, id datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error)
+
+func (c *dataCollectionRuleAssociationsCache) get(ctx context.Context, id datacollectionruleassociations.DataCollectionRuleId, list listDataCollectionRuleAssociationsFunc) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+	v, _ := c.entries.LoadOrStore(strings.ToLower(id.ID()), &dataCollectionRuleAssociationsCacheEntry{})
+	entry := v.(*dataCollectionRuleAssociationsCacheEntry)
+
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	if !entry.loaded {
+		items, exists, err := list(ctx, id)
+		if err != nil || !exists {
+			return nil, exists, err
+		}
+		entry.items = items
+		entry.loaded = true
+	}
+
+	return entry.items, true, nil
+}
+
+func (c *dataCollectionRuleAssociationsCache) invalidate(id datacollectionruleassociations.DataCollectionRuleId) {
+	c.entries.Delete(strings.ToLower(id.ID()))
+}
+
+// DataCollectionRuleAssociationsForRule returns the Data Collection Rule Associations of the specified Data Collection
+// Rule, and whether the Data Collection Rule exists. These are listed once per Data Collection Rule for the lifetime of
+// the client (a single Terraform operation) so that refreshing the many associations which commonly reference a single
+// Data Collection Rule doesn't require retrieving each of them individually. Only the associations of a Data Collection
+// Rule which exists are cached, failures aren't.
+func (c *Client) DataCollectionRuleAssociationsForRule(ctx context.Context, id datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+	return c.dataCollectionRuleAssociations.get(ctx, id, c.listDataCollectionRuleAssociationsForRule)
+}
+
+// InvalidateDataCollectionRuleAssociationsForRule removes the cached associations of the specified Data Collection
+// Rule, which must be called when an association referencing it is created, updated or deleted.
+func (c *Client) InvalidateDataCollectionRuleAssociationsForRule(id datacollectionruleassociations.DataCollectionRuleId) {
+	c.dataCollectionRuleAssociations.invalidate(id)
+}
+
+func (c *Client) listDataCollectionRuleAssociationsForRule(ctx context.Context, id datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+	items := make([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, 0)
+
+	resp, err := c.DataCollectionRuleAssociationsClient.ListByRule(ctx, id)
+	for {
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("listing associations of %s: %+v", id, err)
+		}
+
+		if resp.Model != nil {
+			items = append(items, *resp.Model...)
+		}
+		if !resp.HasMore() {
+			break
+		}
+		resp, err = resp.LoadMore(ctx)
+	}
+
+	return items, true, nil
+}
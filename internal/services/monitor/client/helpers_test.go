@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestDataCollectionRuleAssociationsCache(t *testing.T) {
+	ruleId := datacollectionruleassociations.NewDataCollectionRuleID("00000000-0000-0000-0000-000000000000", "group1", "rule1")
+	otherRuleId := datacollectionruleassociations.NewDataCollectionRuleID("00000000-0000-0000-0000-000000000000", "group1", "rule2")
+
+	calls := 0
+	list := func(_ context.Context, id datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+		calls++
+		return []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{
+			{
+				Id: utils.String(fmt.Sprintf("%s/association%d", id.ID(), calls)),
+			},
+		}, true, nil
+	}
+
+	cache := dataCollectionRuleAssociationsCache{}
+	first, exists, err := cache.get(context.TODO(), ruleId, list)
+	if err != nil || !exists || len(first) != 1 {
+		t.Fatalf("expected a single association of an existing rule but got %+v / %t / %+v", first, exists, err)
+	}
+
+	// the casing of the ID of the rule doesn't matter
+	upperRuleId := datacollectionruleassociations.NewDataCollectionRuleID("00000000-0000-0000-0000-000000000000", "GROUP1", "RULE1")
+	second, _, _ := cache.get(context.TODO(), upperRuleId, list)
+	if calls != 1 || *second[0].Id != *first[0].Id {
+		t.Fatalf("expected the associations to be listed once but they were listed %d times", calls)
+	}
+
+	// other rules are listed separately
+	if _, _, err := cache.get(context.TODO(), otherRuleId, list); err != nil || calls != 2 {
+		t.Fatalf("expected the associations of another rule to be listed but they were listed %d times: %+v", calls, err)
+	}
+
+	// invalidating a rule lists its associations again, without affecting other rules
+	cache.invalidate(ruleId)
+	third, _, _ := cache.get(context.TODO(), ruleId, list)
+	if calls != 3 || *third[0].Id == *first[0].Id {
+		t.Fatalf("expected the associations to be listed again after being invalidated but they were listed %d times", calls)
+	}
+	if _, _, _ = cache.get(context.TODO(), otherRuleId, list); calls != 3 {
+		t.Fatalf("expected the associations of another rule to remain cached but they were listed %d times", calls)
+	}
+}
+
+func TestDataCollectionRuleAssociationsCacheDoesNotCacheFailures(t *testing.T) {
+	ruleId := datacollectionruleassociations.NewDataCollectionRuleID("00000000-0000-0000-0000-000000000000", "group1", "rule1")
+
+	cases := []struct {
+		Name   string
+		Exists bool
+		Err    error
+	}{
+		{
+			Name: "error",
+			Err:  fmt.Errorf("listing associations: context canceled"),
+		},
+		{
+			Name:   "rule not found",
+			Exists: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			calls := 0
+			list := func(_ context.Context, _ datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+				calls++
+				if calls == 1 {
+					return nil, tc.Exists, tc.Err
+				}
+				return []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{}, true, nil
+			}
+
+			cache := dataCollectionRuleAssociationsCache{}
+			if _, exists, err := cache.get(context.TODO(), ruleId, list); exists || err != tc.Err {
+				t.Fatalf("expected the first listing to return %t / %+v but got %t / %+v", tc.Exists, tc.Err, exists, err)
+			}
+
+			if _, exists, err := cache.get(context.TODO(), ruleId, list); !exists || err != nil || calls != 2 {
+				t.Fatalf("expected the associations to be listed again but got %t / %+v after %d listings", exists, err, calls)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package monitor
 
 import (
+	"strings"
+
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2018-04-16/scheduledqueryrules"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -113,3 +115,25 @@ func expandStringValues(input []interface{}) []string {
 	}
 	return result
 }
+
+// monitorScopeResourceType returns the fully qualified resource type (e.g. `Microsoft.Compute/virtualMachines`, or
+// `Microsoft.Storage/storageAccounts/queueServices` for a nested resource) of the specified scope or resource ID in its
+// original casing, or an empty string when the scope is a Subscription or Resource Group.
+func monitorScopeResourceType(input string) string {
+	index := strings.LastIndex(strings.ToLower(input), "/providers/")
+	if index == -1 {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(input[index+len("/providers/"):], "/"), "/")
+	if len(segments) < 3 {
+		return ""
+	}
+
+	types := []string{segments[0]}
+	for i := 1; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+
+	return strings.Join(types, "/")
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/aad/mgmt/2017-04-01/aad" // nolint: staticcheck
@@ -42,6 +44,8 @@ func resourceMonitorAADDiagnosticSetting() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorAADDiagnosticSettingCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -54,7 +58,6 @@ func resourceMonitorAADDiagnosticSetting() *pluginsdk.Resource {
 			"eventhub_name": {
 				Type:     pluginsdk.TypeString,
 				Optional: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringMatch(
 					regexp.MustCompile("^[a-zA-Z0-9]([-._a-zA-Z0-9]{0,48}[a-zA-Z0-9])?$"),
 					"The event hub name can contain only letters, numbers, periods (.), hyphens (-),and underscores (_), up to 50 characters, and it must begin and end with a letter or number.",
@@ -64,7 +67,6 @@ func resourceMonitorAADDiagnosticSetting() *pluginsdk.Resource {
 			"eventhub_authorization_rule_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: authRuleParse.ValidateAuthorizationRuleID,
 				AtLeastOneOf: []string{"eventhub_authorization_rule_id", "log_analytics_workspace_id", "storage_account_id"},
 			},
@@ -79,7 +81,6 @@ func resourceMonitorAADDiagnosticSetting() *pluginsdk.Resource {
 			"storage_account_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: storageaccounts.ValidateStorageAccountID,
 				AtLeastOneOf: []string{"eventhub_authorization_rule_id", "log_analytics_workspace_id", "storage_account_id"},
 			},
@@ -172,6 +173,83 @@ func resourceMonitorAADDiagnosticSetting() *pluginsdk.Resource {
 	return resource
 }
 
+// monitorAADDiagnosticSettingCustomizeDiff validates the configured log categories against those supported by the
+// tenant, since the API silently drops unsupported categories which then never appear in the state. This is only done
+// when the logs have changed, and is skipped when the supported categories can't be listed.
+func monitorAADDiagnosticSettingCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	keys := []string{"enabled_log"}
+	if !features.FourPointOhBeta() {
+		keys = append(keys, "log")
+	}
+	changed := false
+	for _, key := range keys {
+		if d.HasChange(key) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	configured := make([]string, 0)
+	for _, key := range keys {
+		blocks := config.GetAttr(key)
+		if !blocks.IsKnown() || blocks.IsNull() {
+			continue
+		}
+
+		for it := blocks.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() || block.IsNull() {
+				continue
+			}
+			if category := block.GetAttr("category"); category.IsKnown() && !category.IsNull() {
+				configured = append(configured, category.AsString())
+			}
+		}
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	client := meta.(*clients.Client).Monitor.AADDiagnosticSettingsCategoryClient
+	resp, err := client.List(ctx)
+	if err != nil {
+		log.Printf("[WARN] skipping validation of the log categories since the AAD Diagnostic Setting categories couldn't be listed: %+v", err)
+		return nil
+	}
+
+	supported := make([]string, 0)
+	if resp.Value != nil {
+		for _, v := range *resp.Value {
+			if v.Name != nil {
+				supported = append(supported, *v.Name)
+			}
+		}
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+	sort.Strings(supported)
+
+	for _, category := range configured {
+		found := false
+		for _, v := range supported {
+			if strings.EqualFold(v, category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the log category %q is not supported, supported categories are: %s", category, strings.Join(supported, ", "))
+		}
+	}
+
+	return nil
+}
+
 func resourceMonitorAADDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.AADDiagnosticSettingsClient
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
@@ -188,7 +266,7 @@ func resourceMonitorAADDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta i
 	}
 
 	if !utils.ResponseWasNotFound(existing.Response) {
-		return tf.ImportAsExistsError("azurerm_monitor_aad_diagnostic_setting", id.ID())
+		return monitorAADDiagnosticSettingImportAsExistsError(id)
 	}
 
 	// If there is no `enabled` log entry, the PUT will succeed while the next GET will return a 404.
@@ -241,12 +319,15 @@ func resourceMonitorAADDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta i
 		properties.DiagnosticSettings.StorageAccountID = utils.String(storageAccountId)
 	}
 
+	// the ID is set ahead of the PUT since the setting can be created even when the request errors (e.g. it times
+	// out), in which case it's tracked as tainted rather than blocking the next apply with a requires import error -
+	// if it doesn't exist the next Read will remove it from the state
+	d.SetId(id.ID())
+
 	if _, err := client.CreateOrUpdate(ctx, properties, id.Name); err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
 
-	d.SetId(id.ID())
-
 	return resourceMonitorAADDiagnosticSettingRead(d, meta)
 }
 
@@ -327,7 +408,7 @@ func resourceMonitorAADDiagnosticSettingUpdate(d *pluginsdk.ResourceData, meta i
 	}
 
 	if _, err := client.CreateOrUpdate(ctx, properties, id.Name); err != nil {
-		return fmt.Errorf("creating %s: %+v", id, err)
+		return fmt.Errorf("updating %s: %+v", id, err)
 	}
 
 	return resourceMonitorAADDiagnosticSettingRead(d, meta)
@@ -453,6 +534,12 @@ func monitorAADDiagnosticSettingDeletedRefreshFunc(ctx context.Context, client *
 	}
 }
 
+// monitorAADDiagnosticSettingImportAsExistsError returns the requires import error including the exact import command,
+// since the ID of this resource is synthesized by the provider rather than shown in the Portal.
+func monitorAADDiagnosticSettingImportAsExistsError(id parse.MonitorAADDiagnosticSettingId) error {
+	return fmt.Errorf("%+v\n\nThe existing AAD Diagnostic Setting can be imported using:\n\n  terraform import azurerm_monitor_aad_diagnostic_setting.<name> %s", tf.ImportAsExistsError("azurerm_monitor_aad_diagnostic_setting", id.ID()), id.ID())
+}
+
 func expandMonitorAADDiagnosticsSettingsLogs(input []interface{}) []aad.LogSettings {
 	results := make([]aad.LogSettings, 0)
 
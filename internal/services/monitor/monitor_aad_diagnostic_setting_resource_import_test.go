@@ -0,0 +1,24 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/parse"
+)
+
+func TestMonitorAADDiagnosticSettingImportAsExistsError(t *testing.T) {
+	id := parse.NewMonitorAADDiagnosticSettingID("setting1")
+
+	actual := monitorAADDiagnosticSettingImportAsExistsError(id).Error()
+
+	expected := "terraform import azurerm_monitor_aad_diagnostic_setting.<name> /providers/Microsoft.AADIAM/diagnosticSettings/setting1"
+	if !strings.Contains(actual, expected) {
+		t.Fatalf("expected the error to contain %q but got %q", expected, actual)
+	}
+
+	// the generic requires import error must still be returned, since this is what's matched by the acceptance tests
+	if !strings.Contains(actual, "already exists - to be managed via Terraform this resource needs to be imported into the State") {
+		t.Fatalf("expected the error to contain the requires import error but got %q", actual)
+	}
+}
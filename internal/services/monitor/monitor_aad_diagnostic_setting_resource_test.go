@@ -24,14 +24,18 @@ type MonitorAADDiagnosticSettingResource struct{}
 func TestAccMonitorAADDiagnosticSetting(t *testing.T) {
 	testCases := map[string]map[string]func(t *testing.T){
 		"basic": {
-			"eventhubDefault":       testAccMonitorAADDiagnosticSetting_eventhubDefault,
-			"eventhub":              testAccMonitorAADDiagnosticSetting_eventhub,
-			"requiresImport":        testAccMonitorAADDiagnosticSetting_requiresImport,
-			"logAnalyticsWorkspace": testAccMonitorAADDiagnosticSetting_logAnalyticsWorkspace,
-			"storageAccount":        testAccMonitorAADDiagnosticSetting_storageAccount,
-			"storageAccountUpdate":  testAccMonitorAADDiagnosticSetting_updateToEnabledLog,
-			"updateEnabledLog":      testAccMonitorAADDiagnosticSetting_updateEnabledLog,
-			"updateToDisabled":      testAccMonitorAADDiagnosticSetting_updateToDisabled, // remove this test in 4.0 version
+			"eventhubDefault":        testAccMonitorAADDiagnosticSetting_eventhubDefault,
+			"eventhub":               testAccMonitorAADDiagnosticSetting_eventhub,
+			"requiresImport":         testAccMonitorAADDiagnosticSetting_requiresImport,
+			"logAnalyticsWorkspace":  testAccMonitorAADDiagnosticSetting_logAnalyticsWorkspace,
+			"multipleWorkspaces":     testAccMonitorAADDiagnosticSetting_multipleWorkspaces,
+			"storageAccount":         testAccMonitorAADDiagnosticSetting_storageAccount,
+			"storageAccountUpdate":   testAccMonitorAADDiagnosticSetting_updateToEnabledLog,
+			"updateEnabledLog":       testAccMonitorAADDiagnosticSetting_updateEnabledLog,
+			"updateEventhub":         testAccMonitorAADDiagnosticSetting_updateEventhub,
+			"unsupportedCategory":    testAccMonitorAADDiagnosticSetting_unsupportedCategory,
+			"updateToDisabled":       testAccMonitorAADDiagnosticSetting_updateToDisabled,       // remove this test in 4.0 version
+			"migrateLogToEnabledLog": testAccMonitorAADDiagnosticSetting_migrateLogToEnabledLog, // remove this test in 4.0 version
 		},
 	}
 
@@ -76,6 +80,45 @@ func testAccMonitorAADDiagnosticSetting_eventhub(t *testing.T) {
 	})
 }
 
+func testAccMonitorAADDiagnosticSetting_updateEventhub(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
+	r := MonitorAADDiagnosticSettingResource{}
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.eventhubDefault(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.eventhub(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.eventhubDefault(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func testAccMonitorAADDiagnosticSetting_unsupportedCategory(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
+	r := MonitorAADDiagnosticSettingResource{}
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.unsupportedCategory(data),
+			ExpectError: regexp.MustCompile("the log category \"SignInLog\" is not supported"),
+		},
+	})
+}
+
 func testAccMonitorAADDiagnosticSetting_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
 	r := MonitorAADDiagnosticSettingResource{}
@@ -109,6 +152,24 @@ func testAccMonitorAADDiagnosticSetting_logAnalyticsWorkspace(t *testing.T) {
 	})
 }
 
+func testAccMonitorAADDiagnosticSetting_multipleWorkspaces(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
+	secondary := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "secondary")
+	r := MonitorAADDiagnosticSettingResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.multipleWorkspaces(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(secondary.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		secondary.ImportStep(),
+	})
+}
+
 func testAccMonitorAADDiagnosticSetting_storageAccount(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
 	r := MonitorAADDiagnosticSettingResource{}
@@ -160,6 +221,39 @@ func testAccMonitorAADDiagnosticSetting_updateToEnabledLog(t *testing.T) {
 	})
 }
 
+func testAccMonitorAADDiagnosticSetting_migrateLogToEnabledLog(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
+	r := MonitorAADDiagnosticSettingResource{}
+
+	if features.FourPointOhBeta() {
+		t.Skip("remove this test in 4.0 version")
+	}
+
+	// replacing the `log` blocks with `enabled_log` blocks for the enabled categories shouldn't require any changes,
+	// since `enabled_log` is populated from the same API response (including the `retention_policy`)
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.legacyLog(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("enabled_log.#").HasValue("2"),
+			),
+		},
+		{
+			Config:   r.legacyLogMigrated(data),
+			PlanOnly: true,
+		},
+		{
+			Config: r.legacyLogMigrated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("enabled_log.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func testAccMonitorAADDiagnosticSetting_updateEnabledLog(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_aad_diagnostic_setting", "test")
 	r := MonitorAADDiagnosticSettingResource{}
@@ -491,6 +585,57 @@ resource "azurerm_monitor_aad_diagnostic_setting" "test" {
 `, data.RandomInteger, data.Locations.Primary)
 }
 
+func (MonitorAADDiagnosticSettingResource) multipleWorkspaces(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-LAW-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "PerGB2018"
+  retention_in_days   = 30
+}
+
+resource "azurerm_log_analytics_workspace" "secondary" {
+  name                = "acctest-LAW2-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "PerGB2018"
+  retention_in_days   = 30
+}
+
+resource "azurerm_monitor_aad_diagnostic_setting" "test" {
+  name                       = "acctest-DS-%[1]d"
+  log_analytics_workspace_id = azurerm_log_analytics_workspace.test.id
+  enabled_log {
+    category = "SignInLogs"
+    retention_policy {}
+  }
+  enabled_log {
+    category = "AuditLogs"
+    retention_policy {}
+  }
+}
+
+resource "azurerm_monitor_aad_diagnostic_setting" "secondary" {
+  name                       = "acctest-DS2-%[1]d"
+  log_analytics_workspace_id = azurerm_log_analytics_workspace.secondary.id
+  enabled_log {
+    category = "AuditLogs"
+    retention_policy {}
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (MonitorAADDiagnosticSettingResource) storageAccount(data acceptance.TestData) string {
 	if !features.FourPointOhBeta() {
 		return fmt.Sprintf(`
@@ -772,6 +917,37 @@ resource "azurerm_monitor_aad_diagnostic_setting" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomStringOfLength(5))
 }
 
+func (MonitorAADDiagnosticSettingResource) unsupportedCategory(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_kind             = "StorageV2"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_aad_diagnostic_setting" "test" {
+  name               = "acctest-DS-%[1]d"
+  storage_account_id = azurerm_storage_account.test.id
+  enabled_log {
+    category = "SignInLog"
+    retention_policy {}
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomStringOfLength(5))
+}
+
 func (MonitorAADDiagnosticSettingResource) retentionDisabled(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -814,6 +990,81 @@ resource "azurerm_monitor_aad_diagnostic_setting" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomStringOfLength(5))
 }
 
+// remove this in 4.0 version
+func (MonitorAADDiagnosticSettingResource) legacyLog(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_monitor_aad_diagnostic_setting" "test" {
+  name               = "acctest-DS-%[2]d"
+  storage_account_id = azurerm_storage_account.test.id
+  log {
+    category = "AuditLogs"
+    enabled  = true
+    retention_policy {
+      enabled = true
+      days    = 1
+    }
+  }
+  log {
+    category = "SignInLogs"
+    enabled  = true
+    retention_policy {}
+  }
+  log {
+    category = "ProvisioningLogs"
+    enabled  = false
+    retention_policy {}
+  }
+}
+`, MonitorAADDiagnosticSettingResource{}.storageAccountTemplate(data), data.RandomInteger)
+}
+
+// remove this in 4.0 version
+func (MonitorAADDiagnosticSettingResource) legacyLogMigrated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_monitor_aad_diagnostic_setting" "test" {
+  name               = "acctest-DS-%[2]d"
+  storage_account_id = azurerm_storage_account.test.id
+  enabled_log {
+    category = "AuditLogs"
+    retention_policy {
+      enabled = true
+      days    = 1
+    }
+  }
+  enabled_log {
+    category = "SignInLogs"
+    retention_policy {}
+  }
+}
+`, MonitorAADDiagnosticSettingResource{}.storageAccountTemplate(data), data.RandomInteger)
+}
+
+func (MonitorAADDiagnosticSettingResource) storageAccountTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_kind             = "StorageV2"
+  account_replication_type = "LRS"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomStringOfLength(5))
+}
+
 // remove this in 4.0 version
 func (MonitorAADDiagnosticSettingResource) disabled(data acceptance.TestData) string {
 	return fmt.Sprintf(`
@@ -242,7 +242,7 @@ resource "azurerm_monitor_action_group" "test" {
 
   webhook_receiver {
     name        = "callmyapiaswell"
-    service_uri = "http://example.com/alert"
+    service_uri = "https://example.com/alert"
   }
 
   webhook_receiver {
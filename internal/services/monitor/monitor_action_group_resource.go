@@ -1,8 +1,11 @@
 package monitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -11,12 +14,15 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2021-06-22/automationaccount"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/eventhub/2021-11-01/eventhubs"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2023-01-01/actiongroupsapis"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/logic/2019-05-01/workflows"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/logic/2019-05-01/workflowtriggers"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	appServiceValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/appservice/validate"
+	eventhubValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/eventhub/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
@@ -43,6 +49,8 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 			0: migration.ActionGroupUpgradeV0ToV1{},
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorActionGroupCustomizeDiff),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -55,7 +63,7 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 				Type:         pluginsdk.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+				ValidateFunc: validate.ActionGroupName,
 			},
 
 			"resource_group_name": commonschema.ResourceGroupName(),
@@ -69,7 +77,7 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 					location.EnhancedValidate,
 					validation.StringInSlice([]string{
 						"global",
-					}, false),
+					}, true),
 				),
 				StateFunc:        location.StateFunc,
 				DiffSuppressFunc: location.DiffSuppressFunc,
@@ -209,6 +217,11 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 							Optional: true,
 						},
 
+						"insecure_http_enabled": {
+							Type:     pluginsdk.TypeBool,
+							Optional: true,
+						},
+
 						"aad_auth": {
 							Type:     pluginsdk.TypeList,
 							Optional: true,
@@ -297,7 +310,7 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 						"country_code": {
 							Type:         pluginsdk.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
+							ValidateFunc: validate.ActionGroupVoiceReceiverCountryCode,
 						},
 						"phone_number": {
 							Type:         pluginsdk.TypeString,
@@ -311,6 +324,9 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 			"logic_app_receiver": {
 				Type:     pluginsdk.TypeList,
 				Optional: true,
+				// Computed so that the callback URL of receivers using `trigger_name` can be resolved during plan, see
+				// monitorActionGroupResolveLogicAppCallbackUrls
+				Computed: true,
 				Elem: &pluginsdk.Resource{
 					Schema: map[string]*pluginsdk.Schema{
 						"name": {
@@ -325,9 +341,15 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 						},
 						"callback_url": {
 							Type:         pluginsdk.TypeString,
-							Required:     true,
+							Optional:     true,
+							Computed:     true,
 							ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
 						},
+						"trigger_name": {
+							Type:         pluginsdk.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
 						"use_common_alert_schema": {
 							Type:     pluginsdk.TypeBool,
 							Optional: true,
@@ -381,9 +403,15 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 							ValidateFunc: validation.StringIsNotEmpty,
 						},
 						"role_id": {
-							Type:         pluginsdk.TypeString,
-							Required:     true,
-							ValidateFunc: validation.IsUUID,
+							Type:     pluginsdk.TypeString,
+							Required: true,
+							ValidateFunc: validation.Any(
+								validation.IsUUID,
+								validation.StringInSlice(monitorActionGroupArmRoleNames(), true),
+							),
+							StateFunc: func(v interface{}) string {
+								return monitorActionGroupArmRoleId(v.(string))
+							},
 						},
 						"use_common_alert_schema": {
 							Type:     pluginsdk.TypeBool,
@@ -419,13 +447,13 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 						Type:         pluginsdk.TypeString,
 						Optional:     true,
 						Computed:     true,
-						ValidateFunc: validation.StringIsNotEmpty,
+						ValidateFunc: eventhubValidate.ValidateEventHubName(),
 					},
 					"event_hub_namespace": {
 						Type:         pluginsdk.TypeString,
 						Optional:     true,
 						Computed:     true,
-						ValidateFunc: validation.StringIsNotEmpty,
+						ValidateFunc: eventhubValidate.ValidateEventHubNamespaceName(),
 					},
 					"tenant_id": {
 						Type:         pluginsdk.TypeString,
@@ -460,12 +488,12 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 					"event_hub_name": {
 						Type:         pluginsdk.TypeString,
 						Required:     true,
-						ValidateFunc: validation.StringIsNotEmpty,
+						ValidateFunc: eventhubValidate.ValidateEventHubName(),
 					},
 					"event_hub_namespace": {
 						Type:         pluginsdk.TypeString,
 						Required:     true,
-						ValidateFunc: validation.StringIsNotEmpty,
+						ValidateFunc: eventhubValidate.ValidateEventHubNamespaceName(),
 					},
 					"tenant_id": {
 						Type:         pluginsdk.TypeString,
@@ -490,6 +518,161 @@ func resourceMonitorActionGroup() *pluginsdk.Resource {
 	return resource
 }
 
+// monitorActionGroupReceiverLimits are the maximum number of receivers of each type per Action Group, from
+// https://learn.microsoft.com/azure/azure-monitor/service-limits#action-groups
+var monitorActionGroupReceiverLimits = map[string]int{
+	"automation_runbook_receiver": 10,
+	"azure_app_push_receiver":     10,
+	"azure_function_receiver":     10,
+	"email_receiver":              1000,
+	"itsm_receiver":               10,
+	"logic_app_receiver":          10,
+	"sms_receiver":                10,
+	"voice_receiver":              10,
+	"webhook_receiver":            10,
+}
+
+// monitorActionGroupCustomizeDiff validates the receivers which can't be validated using the schema alone. Any value
+// which isn't known yet is skipped.
+func monitorActionGroupCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+
+	// the API rejects Action Groups with more receivers of a given type than the documented service limits
+	receiverTypes := make([]string, 0, len(monitorActionGroupReceiverLimits))
+	for key := range monitorActionGroupReceiverLimits {
+		receiverTypes = append(receiverTypes, key)
+	}
+	sort.Strings(receiverTypes)
+	for _, key := range receiverTypes {
+		receivers := config.GetAttr(key)
+		if !receivers.IsKnown() || receivers.IsNull() {
+			continue
+		}
+		if limit := monitorActionGroupReceiverLimits[key]; receivers.LengthInt() > limit {
+			return fmt.Errorf("an Action Group can have at most %d `%s` blocks, got %d - split these receivers across multiple Action Groups", limit, key, receivers.LengthInt())
+		}
+	}
+
+	// webhooks using plain HTTP are rejected unless explicitly allowed using `insecure_http_enabled` - prior to 4.0
+	// plain HTTP is allowed unless this is set to `false`
+	if receivers := config.GetAttr("webhook_receiver"); receivers.IsKnown() && !receivers.IsNull() {
+		for it := receivers.ElementIterator(); it.Next(); {
+			_, receiver := it.Element()
+			if !receiver.IsKnown() || receiver.IsNull() {
+				continue
+			}
+
+			serviceUri := receiver.GetAttr("service_uri")
+			if !serviceUri.IsKnown() || serviceUri.IsNull() || !strings.HasPrefix(strings.ToLower(serviceUri.AsString()), "http://") {
+				continue
+			}
+
+			insecureHttpEnabled := receiver.GetAttr("insecure_http_enabled")
+			if !insecureHttpEnabled.IsKnown() {
+				continue
+			}
+			allowed := !features.FourPointOhBeta()
+			if !insecureHttpEnabled.IsNull() {
+				allowed = insecureHttpEnabled.True()
+			}
+
+			if !allowed {
+				name := ""
+				if v := receiver.GetAttr("name"); v.IsKnown() && !v.IsNull() {
+					name = v.AsString()
+				}
+				return fmt.Errorf("the `service_uri` of the `webhook_receiver` %q must use `https`, since alert data would otherwise be sent in plain text - if this is intended, set `insecure_http_enabled` to `true`", name)
+			}
+		}
+	}
+
+	// a Logic App receiver either specifies the `callback_url` or the `trigger_name` to retrieve it from
+	if receivers := config.GetAttr("logic_app_receiver"); receivers.IsKnown() && !receivers.IsNull() {
+		i := 0
+		for it := receivers.ElementIterator(); it.Next(); i++ {
+			_, receiver := it.Element()
+			if !receiver.IsKnown() || receiver.IsNull() {
+				continue
+			}
+
+			callbackUrl := receiver.GetAttr("callback_url")
+			triggerName := receiver.GetAttr("trigger_name")
+			if !callbackUrl.IsKnown() || !triggerName.IsKnown() {
+				continue
+			}
+
+			if callbackUrl.IsNull() == triggerName.IsNull() {
+				return fmt.Errorf("exactly one of `logic_app_receiver.%d.callback_url` or `logic_app_receiver.%d.trigger_name` must be specified", i, i)
+			}
+		}
+	}
+
+	return monitorActionGroupResolveLogicAppCallbackUrls(ctx, d, meta.(*clients.Client).Logic.TriggersClient)
+}
+
+// monitorActionGroupResolveLogicAppCallbackUrls retrieves the current callback URL of each Logic App receiver which
+// specifies a `trigger_name`, so that a callback URL which has been regenerated (e.g. when the access keys of the Logic
+// App are regenerated) shows up as a diff and is updated. Since `logic_app_receiver` is Computed, removing every block
+// from the configuration is also handled here.
+func monitorActionGroupResolveLogicAppCallbackUrls(ctx context.Context, d *pluginsdk.ResourceDiff, client *workflowtriggers.WorkflowTriggersClient) error {
+	config := d.GetRawConfig().GetAttr("logic_app_receiver")
+	if !config.IsWhollyKnown() {
+		return nil
+	}
+
+	if config.IsNull() || config.LengthInt() == 0 {
+		if old, _ := d.GetChange("logic_app_receiver"); len(old.([]interface{})) > 0 {
+			return d.SetNew("logic_app_receiver", []interface{}{})
+		}
+		return nil
+	}
+
+	receivers := d.Get("logic_app_receiver").([]interface{})
+	changed := false
+	for _, raw := range receivers {
+		receiver, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		triggerName := receiver["trigger_name"].(string)
+		if triggerName == "" {
+			continue
+		}
+
+		callbackUrl, err := retrieveMonitorActionGroupLogicAppCallbackUrl(ctx, client, receiver["name"].(string), receiver["resource_id"].(string), triggerName)
+		if err != nil {
+			return err
+		}
+		if receiver["callback_url"].(string) != callbackUrl {
+			receiver["callback_url"] = callbackUrl
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return d.SetNew("logic_app_receiver", receivers)
+}
+
+func retrieveMonitorActionGroupLogicAppCallbackUrl(ctx context.Context, client *workflowtriggers.WorkflowTriggersClient, receiverName, resourceId, triggerName string) (string, error) {
+	workflowId, err := workflows.ParseWorkflowIDInsensitively(resourceId)
+	if err != nil {
+		return "", fmt.Errorf("parsing `resource_id` of `logic_app_receiver` %q: %+v", receiverName, err)
+	}
+
+	triggerId := workflowtriggers.NewTriggerID(workflowId.SubscriptionId, workflowId.ResourceGroupName, workflowId.WorkflowName, triggerName)
+	resp, err := client.ListCallbackUrl(ctx, triggerId)
+	if err != nil {
+		return "", fmt.Errorf("retrieving callback URL for %s: %+v", triggerId, err)
+	}
+	if resp.Model == nil || resp.Model.Value == nil {
+		return "", fmt.Errorf("retrieving callback URL for %s: `value` was nil", triggerId)
+	}
+
+	return *resp.Model.Value, nil
+}
+
 func resourceMonitorActionGroupCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.ActionGroupsClient
 	tenantId := meta.(*clients.Client).Account.TenantId
@@ -537,6 +720,11 @@ func resourceMonitorActionGroupCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
+	expandedLogicAppReceiver, err := expandMonitorActionGroupLogicAppReceiver(ctx, meta.(*clients.Client).Logic.TriggersClient, logicAppReceiversRaw)
+	if err != nil {
+		return err
+	}
+
 	t := d.Get("tags").(map[string]interface{})
 
 	parameters := actiongroupsapis.ActionGroupResource{
@@ -551,7 +739,7 @@ func resourceMonitorActionGroupCreateUpdate(d *pluginsdk.ResourceData, meta inte
 			WebhookReceivers:           expandMonitorActionGroupWebHookReceiver(tenantId, webhookReceiversRaw),
 			AutomationRunbookReceivers: expandMonitorActionGroupAutomationRunbookReceiver(automationRunbookReceiversRaw),
 			VoiceReceivers:             expandMonitorActionGroupVoiceReceiver(voiceReceiversRaw),
-			LogicAppReceivers:          expandMonitorActionGroupLogicAppReceiver(logicAppReceiversRaw),
+			LogicAppReceivers:          expandedLogicAppReceiver,
 			AzureFunctionReceivers:     expandMonitorActionGroupAzureFunctionReceiver(azureFunctionReceiversRaw),
 			ArmRoleReceivers:           expandMonitorActionGroupRoleReceiver(armRoleReceiversRaw),
 			EventHubReceivers:          expandedEventHubReceiver,
@@ -598,45 +786,47 @@ func resourceMonitorActionGroupRead(d *pluginsdk.ResourceData, meta interface{})
 			d.Set("short_name", props.GroupShortName)
 			d.Set("enabled", props.Enabled)
 
-			if err = d.Set("email_receiver", flattenMonitorActionGroupEmailReceiver(props.EmailReceivers)); err != nil {
+			if err = d.Set("email_receiver", orderMonitorActionGroupReceivers(d.Get("email_receiver").([]interface{}), flattenMonitorActionGroupEmailReceiver(props.EmailReceivers))); err != nil {
 				return fmt.Errorf("setting `email_receiver`: %+v", err)
 			}
 
-			if err = d.Set("itsm_receiver", flattenMonitorActionGroupItsmReceiver(props.ItsmReceivers)); err != nil {
+			if err = d.Set("itsm_receiver", orderMonitorActionGroupReceivers(d.Get("itsm_receiver").([]interface{}), flattenMonitorActionGroupItsmReceiver(props.ItsmReceivers))); err != nil {
 				return fmt.Errorf("setting `itsm_receiver`: %+v", err)
 			}
 
-			if err = d.Set("azure_app_push_receiver", flattenMonitorActionGroupAzureAppPushReceiver(props.AzureAppPushReceivers)); err != nil {
+			if err = d.Set("azure_app_push_receiver", orderMonitorActionGroupReceivers(d.Get("azure_app_push_receiver").([]interface{}), flattenMonitorActionGroupAzureAppPushReceiver(props.AzureAppPushReceivers))); err != nil {
 				return fmt.Errorf("setting `azure_app_push_receiver`: %+v", err)
 			}
 
-			if err = d.Set("sms_receiver", flattenMonitorActionGroupSmsReceiver(props.SmsReceivers)); err != nil {
+			if err = d.Set("sms_receiver", orderMonitorActionGroupReceivers(d.Get("sms_receiver").([]interface{}), flattenMonitorActionGroupSmsReceiver(props.SmsReceivers))); err != nil {
 				return fmt.Errorf("setting `sms_receiver`: %+v", err)
 			}
 
-			if err = d.Set("webhook_receiver", flattenMonitorActionGroupWebHookReceiver(props.WebhookReceivers)); err != nil {
+			webhookReceivers := d.Get("webhook_receiver").([]interface{})
+			if err = d.Set("webhook_receiver", orderMonitorActionGroupReceivers(webhookReceivers, setMonitorActionGroupReceiverFieldsFromState(webhookReceivers, flattenMonitorActionGroupWebHookReceiver(props.WebhookReceivers), "insecure_http_enabled"))); err != nil {
 				return fmt.Errorf("setting `webhook_receiver`: %+v", err)
 			}
 
-			if err = d.Set("automation_runbook_receiver", flattenMonitorActionGroupAutomationRunbookReceiver(props.AutomationRunbookReceivers)); err != nil {
+			if err = d.Set("automation_runbook_receiver", orderMonitorActionGroupReceivers(d.Get("automation_runbook_receiver").([]interface{}), flattenMonitorActionGroupAutomationRunbookReceiver(props.AutomationRunbookReceivers))); err != nil {
 				return fmt.Errorf("setting `automation_runbook_receiver`: %+v", err)
 			}
 
-			if err = d.Set("voice_receiver", flattenMonitorActionGroupVoiceReceiver(props.VoiceReceivers)); err != nil {
+			if err = d.Set("voice_receiver", orderMonitorActionGroupReceivers(d.Get("voice_receiver").([]interface{}), flattenMonitorActionGroupVoiceReceiver(props.VoiceReceivers))); err != nil {
 				return fmt.Errorf("setting `voice_receiver`: %+v", err)
 			}
 
-			if err = d.Set("logic_app_receiver", flattenMonitorActionGroupLogicAppReceiver(props.LogicAppReceivers)); err != nil {
+			logicAppReceivers := d.Get("logic_app_receiver").([]interface{})
+			if err = d.Set("logic_app_receiver", orderMonitorActionGroupReceivers(logicAppReceivers, setMonitorActionGroupReceiverFieldsFromState(logicAppReceivers, flattenMonitorActionGroupLogicAppReceiver(props.LogicAppReceivers), "trigger_name"))); err != nil {
 				return fmt.Errorf("setting `logic_app_receiver`: %+v", err)
 			}
 
-			if err = d.Set("azure_function_receiver", flattenMonitorActionGroupAzureFunctionReceiver(props.AzureFunctionReceivers)); err != nil {
+			if err = d.Set("azure_function_receiver", orderMonitorActionGroupReceivers(d.Get("azure_function_receiver").([]interface{}), flattenMonitorActionGroupAzureFunctionReceiver(props.AzureFunctionReceivers))); err != nil {
 				return fmt.Errorf("setting `azure_function_receiver`: %+v", err)
 			}
-			if err = d.Set("arm_role_receiver", flattenMonitorActionGroupRoleReceiver(props.ArmRoleReceivers)); err != nil {
+			if err = d.Set("arm_role_receiver", orderMonitorActionGroupReceivers(d.Get("arm_role_receiver").([]interface{}), flattenMonitorActionGroupRoleReceiver(props.ArmRoleReceivers))); err != nil {
 				return fmt.Errorf("setting `arm_role_receiver`: %+v", err)
 			}
-			if err = d.Set("event_hub_receiver", flattenMonitorActionGroupEventHubReceiver(id.ResourceGroupName, props.EventHubReceivers)); err != nil {
+			if err = d.Set("event_hub_receiver", orderMonitorActionGroupReceivers(d.Get("event_hub_receiver").([]interface{}), flattenMonitorActionGroupEventHubReceiver(id.ResourceGroupName, props.EventHubReceivers))); err != nil {
 				return fmt.Errorf("setting `event_hub_receiver`: %+v", err)
 			}
 		}
@@ -647,6 +837,57 @@ func resourceMonitorActionGroupRead(d *pluginsdk.ResourceData, meta interface{})
 	return nil
 }
 
+// orderMonitorActionGroupReceivers sorts the receivers returned from the API into the order they're defined in the
+// existing state/configuration (matched on `name`, which is unique within an Action Group) so that the API returning
+// receivers in a different order doesn't show up as a diff. Receivers which aren't known yet (e.g. when importing) are
+// appended sorted by `name`, so that the order doesn't depend on the API either.
+func orderMonitorActionGroupReceivers(existing []interface{}, flattened []interface{}) []interface{} {
+	byName := make(map[string]interface{})
+	for _, raw := range flattened {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				byName[name] = raw
+			}
+		}
+	}
+
+	results := make([]interface{}, 0, len(flattened))
+	for _, raw := range existing {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				if receiver, ok := byName[name]; ok {
+					results = append(results, receiver)
+					delete(byName, name)
+				}
+			}
+		}
+	}
+
+	remaining := make([]interface{}, 0, len(flattened)-len(results))
+	for _, raw := range flattened {
+		if name := monitorActionGroupReceiverName(raw); name != "" {
+			if _, ok := byName[name]; !ok {
+				continue
+			}
+		}
+		remaining = append(remaining, raw)
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return monitorActionGroupReceiverName(remaining[i]) < monitorActionGroupReceiverName(remaining[j])
+	})
+
+	return append(results, remaining...)
+}
+
+func monitorActionGroupReceiverName(input interface{}) string {
+	if v, ok := input.(map[string]interface{}); ok {
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
 func resourceMonitorActionGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.ActionGroupsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
@@ -796,7 +1037,7 @@ func expandMonitorActionGroupVoiceReceiver(v []interface{}) *[]actiongroupsapis.
 	return &receivers
 }
 
-func expandMonitorActionGroupLogicAppReceiver(v []interface{}) *[]actiongroupsapis.LogicAppReceiver {
+func expandMonitorActionGroupLogicAppReceiver(ctx context.Context, client *workflowtriggers.WorkflowTriggersClient, v []interface{}) (*[]actiongroupsapis.LogicAppReceiver, error) {
 	receivers := make([]actiongroupsapis.LogicAppReceiver, 0)
 	for _, receiverValue := range v {
 		val := receiverValue.(map[string]interface{})
@@ -806,9 +1047,20 @@ func expandMonitorActionGroupLogicAppReceiver(v []interface{}) *[]actiongroupsap
 			CallbackUrl:          val["callback_url"].(string),
 			UseCommonAlertSchema: utils.Bool(val["use_common_alert_schema"].(bool)),
 		}
+
+		// the callback URL of a trigger is resolved during plan, but isn't known yet when e.g. the Logic App is created
+		// in the same apply, in which case it's retrieved now
+		if triggerName := val["trigger_name"].(string); triggerName != "" && receiver.CallbackUrl == "" {
+			callbackUrl, err := retrieveMonitorActionGroupLogicAppCallbackUrl(ctx, client, receiver.Name, receiver.ResourceId, triggerName)
+			if err != nil {
+				return nil, err
+			}
+			receiver.CallbackUrl = callbackUrl
+		}
+
 		receivers = append(receivers, receiver)
 	}
-	return &receivers
+	return &receivers, nil
 }
 
 func expandMonitorActionGroupAzureFunctionReceiver(v []interface{}) *[]actiongroupsapis.AzureFunctionReceiver {
@@ -827,13 +1079,43 @@ func expandMonitorActionGroupAzureFunctionReceiver(v []interface{}) *[]actiongro
 	return &receivers
 }
 
+// monitorActionGroupArmRoleIds contains the IDs of the built-in roles which can be notified by an Action Group, keyed
+// by the name of the role
+var monitorActionGroupArmRoleIds = map[string]string{
+	"Contributor":            "b24988ac-6180-42a0-ab88-20f7382dd24c",
+	"Monitoring Contributor": "749f88d5-cbae-40b8-bcfc-e573ddc772fa",
+	"Monitoring Reader":      "43d0d8ad-25c7-4714-9337-8ba259a9fe05",
+	"Owner":                  "8e3af657-a8ff-443c-a75c-2fe8c4bcb635",
+	"Reader":                 "acdd72a7-3385-48ef-bd42-f606fba81ae7",
+}
+
+func monitorActionGroupArmRoleNames() []string {
+	names := make([]string, 0, len(monitorActionGroupArmRoleIds))
+	for name := range monitorActionGroupArmRoleIds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// monitorActionGroupArmRoleId resolves the name of a built-in role (e.g. `Monitoring Reader`) to the ID of the role,
+// otherwise the role ID is returned lower-cased to match the API
+func monitorActionGroupArmRoleId(input string) string {
+	for name, id := range monitorActionGroupArmRoleIds {
+		if strings.EqualFold(name, input) {
+			return id
+		}
+	}
+	return strings.ToLower(input)
+}
+
 func expandMonitorActionGroupRoleReceiver(v []interface{}) *[]actiongroupsapis.ArmRoleReceiver {
 	receivers := make([]actiongroupsapis.ArmRoleReceiver, 0)
 	for _, receiverValue := range v {
 		val := receiverValue.(map[string]interface{})
 		receiver := actiongroupsapis.ArmRoleReceiver{
 			Name:                 val["name"].(string),
-			RoleId:               val["role_id"].(string),
+			RoleId:               monitorActionGroupArmRoleId(val["role_id"].(string)),
 			UseCommonAlertSchema: utils.Bool(val["use_common_alert_schema"].(bool)),
 		}
 		receivers = append(receivers, receiver)
@@ -990,6 +1272,31 @@ func flattenMonitorActionGroupWebHookReceiver(receivers *[]actiongroupsapis.Webh
 	return result
 }
 
+// setMonitorActionGroupReceiverFieldsFromState carries over the given fields from the existing receivers (matched by
+// name), since these are only used by the provider and aren't returned by the API
+func setMonitorActionGroupReceiverFieldsFromState(existing []interface{}, flattened []interface{}, fields ...string) []interface{} {
+	existingByName := make(map[string]map[string]interface{})
+	for _, raw := range existing {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				existingByName[name] = v
+			}
+		}
+	}
+
+	for _, raw := range flattened {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if name, ok := v["name"].(string); ok {
+				for _, field := range fields {
+					v[field] = existingByName[name][field]
+				}
+			}
+		}
+	}
+
+	return flattened
+}
+
 func flattenMonitorActionGroupSecureWebHookReceiver(receiver actiongroupsapis.WebhookReceiver) []interface{} {
 	if receiver.UseAadAuth == nil || !*receiver.UseAadAuth {
 		return []interface{}{}
@@ -1103,7 +1410,7 @@ func flattenMonitorActionGroupRoleReceiver(receivers *[]actiongroupsapis.ArmRole
 			val := make(map[string]interface{})
 
 			val["name"] = receiver.Name
-			val["role_id"] = receiver.RoleId
+			val["role_id"] = strings.ToLower(receiver.RoleId)
 
 			if receiver.UseCommonAlertSchema != nil {
 				val["use_common_alert_schema"] = *receiver.UseCommonAlertSchema
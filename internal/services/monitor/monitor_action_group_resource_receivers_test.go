@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderMonitorActionGroupReceivers(t *testing.T) {
+	receivers := func(names ...string) []interface{} {
+		results := make([]interface{}, 0)
+		for _, name := range names {
+			results = append(results, map[string]interface{}{
+				"name": name,
+			})
+		}
+		return results
+	}
+
+	cases := []struct {
+		Name      string
+		Existing  []interface{}
+		Flattened []interface{}
+		Expected  []interface{}
+	}{
+		{
+			Name:      "matches the existing order",
+			Existing:  receivers("second", "first", "third"),
+			Flattened: receivers("first", "second", "third"),
+			Expected:  receivers("second", "first", "third"),
+		},
+		{
+			Name:      "import",
+			Existing:  receivers(),
+			Flattened: receivers("second", "third", "first"),
+			Expected:  receivers("first", "second", "third"),
+		},
+		{
+			Name:      "unknown receivers are appended by name",
+			Existing:  receivers("third"),
+			Flattened: receivers("second", "third", "first"),
+			Expected:  receivers("third", "first", "second"),
+		},
+		{
+			Name:      "removed receivers are dropped",
+			Existing:  receivers("second", "first"),
+			Flattened: receivers("first"),
+			Expected:  receivers("first"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := orderMonitorActionGroupReceivers(tc.Existing, tc.Flattened)
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+}
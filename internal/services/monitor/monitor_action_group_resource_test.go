@@ -3,9 +3,13 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2023-01-01/actiongroupsapis"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/logic/2019-05-01/workflows"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/logic/2019-05-01/workflowtriggers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -64,6 +68,31 @@ func TestAccMonitorActionGroup_emailReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_emailReceiversImported(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.emailReceivers(data, "sendtodevops", "sendtoadmin"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:             r.emailReceivers(data, "sendtodevops", "sendtoadmin"),
+			ResourceName:       data.ResourceName,
+			ImportState:        true,
+			ImportStatePersist: true,
+		},
+		{
+			// imported receivers are ordered by name
+			Config:   r.emailReceivers(data, "sendtoadmin", "sendtodevops"),
+			PlanOnly: true,
+		},
+	})
+}
+
 func TestAccMonitorActionGroup_itsmReceiver(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
 	r := MonitorActionGroupResource{}
@@ -124,6 +153,45 @@ func TestAccMonitorActionGroup_webhookReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_webhookReceiverInsecureHttp(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.webhookReceiverInsecureHttp(data, false),
+			ExpectError: regexp.MustCompile("must use `https`"),
+		},
+		{
+			Config: r.webhookReceiverInsecureHttp(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorActionGroup_tooManySmsReceivers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.smsReceivers(data, 11),
+			ExpectError: regexp.MustCompile("an Action Group can have at most 10 `sms_receiver` blocks, got 11"),
+		},
+		{
+			Config: r.smsReceivers(data, 10),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sms_receiver.#").HasValue("10"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 /*
 @favoretti: Disabling this one, since it's written in such a way that it will never succeed in CI
 
@@ -193,6 +261,46 @@ func TestAccMonitorActionGroup_voiceReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_voiceReceiverInvalidCountryCode(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.voiceReceiverCountryCode(data, "+44"),
+			ExpectError: regexp.MustCompile("must be specified without the leading `\\+`"),
+		},
+	})
+}
+
+func TestAccMonitorActionGroup_voiceReceiverPersistsOnEmailReceiverUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.voiceAndEmailReceiver(data, "admin@contoso.com"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("voice_receiver.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.voiceAndEmailReceiver(data, "oncall@contoso.com"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("email_receiver.0.email_address").HasValue("oncall@contoso.com"),
+				check.That(data.ResourceName).Key("voice_receiver.#").HasValue("1"),
+				check.That(data.ResourceName).Key("voice_receiver.0.name").HasValue("oncallmsg"),
+				check.That(data.ResourceName).Key("voice_receiver.0.country_code").HasValue("1"),
+				check.That(data.ResourceName).Key("voice_receiver.0.phone_number").HasValue("2123456789"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorActionGroup_logicAppReceiver(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
 	r := MonitorActionGroupResource{}
@@ -208,6 +316,48 @@ func TestAccMonitorActionGroup_logicAppReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_logicAppReceiverTriggerName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.logicAppReceiverTriggerName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("logic_app_receiver.0.callback_url").IsNotEmpty(),
+			),
+		},
+		// `trigger_name` isn't returned by the API
+		data.ImportStep("logic_app_receiver.0.trigger_name"),
+	})
+}
+
+func TestAccMonitorActionGroup_logicAppReceiverTriggerNameRegenerated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.logicAppReceiverTriggerName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.regenerateLogicAppAccessKey, "azurerm_logic_app_workflow.test"),
+			),
+			// the regenerated callback URL of the trigger shows up as a diff
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: r.logicAppReceiverTriggerName(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.logicAppCallbackUrlMatchesTrigger, data.ResourceName),
+			),
+		},
+		data.ImportStep("logic_app_receiver.0.trigger_name"),
+	})
+}
+
 func TestAccMonitorActionGroup_azureFunctionReceiver(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
 	r := MonitorActionGroupResource{}
@@ -238,6 +388,38 @@ func TestAccMonitorActionGroup_armRoleReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_armRoleReceiverUpperCase(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.armRoleReceiverRoleId(data, "43D0D8AD-25C7-4714-9337-8BA259A9FE05"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("arm_role_receiver.0.role_id").HasValue("43d0d8ad-25c7-4714-9337-8ba259a9fe05"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorActionGroup_armRoleReceiverRoleName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.armRoleReceiverRoleId(data, "Monitoring Reader"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("arm_role_receiver.0.role_id").HasValue("43d0d8ad-25c7-4714-9337-8ba259a9fe05"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorActionGroup_eventHubReceiver(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
 	r := MonitorActionGroupResource{}
@@ -253,6 +435,35 @@ func TestAccMonitorActionGroup_eventHubReceiver(t *testing.T) {
 	})
 }
 
+func TestAccMonitorActionGroup_eventHubReceiverExplicitIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.eventHubReceiverExplicitIds(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("event_hub_receiver.0.subscription_id").Exists(),
+				check.That(data.ResourceName).Key("event_hub_receiver.0.tenant_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorActionGroup_eventHubReceiverInvalidNamespace(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
+	r := MonitorActionGroupResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.eventHubReceiverInvalidNamespace(data),
+			ExpectError: regexp.MustCompile("The namespace name can contain only letters, numbers and hyphens"),
+		},
+	})
+}
+
 func TestAccMonitorActionGroup_complete(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_action_group", "test")
 	r := MonitorActionGroupResource{}
@@ -306,9 +517,18 @@ func TestAccMonitorActionGroup_location(t *testing.T) {
 
 	data.ResourceTest(t, r, []acceptance.TestStep{
 		{
-			Config: r.location(data),
+			Config: r.location(data, "Global"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("location").HasValue("global"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.location(data, "swedencentral"),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("location").HasValue("swedencentral"),
 			),
 		},
 		data.ImportStep(),
@@ -491,6 +711,36 @@ resource "azurerm_monitor_action_group" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) emailReceivers(data acceptance.TestData, names ...string) string {
+	receivers := ""
+	for _, name := range names {
+		receivers += fmt.Sprintf(`
+  email_receiver {
+    name          = "%[1]s"
+    email_address = "%[1]s@contoso.com"
+  }
+`, name)
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+%s
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, receivers)
+}
+
 func (MonitorActionGroupResource) itsmReceiver(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -595,13 +845,66 @@ resource "azurerm_monitor_action_group" "test" {
 
   webhook_receiver {
     name                    = "callmyapiaswell"
-    service_uri             = "http://example.com/alert"
+    service_uri             = "https://example.com/alert"
     use_common_alert_schema = true
   }
 }
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) webhookReceiverInsecureHttp(data acceptance.TestData, insecureHttpEnabled bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  webhook_receiver {
+    name                  = "callmyapiaswell"
+    service_uri           = "http://example.com/alert"
+    insecure_http_enabled = %t
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, insecureHttpEnabled)
+}
+
+func (MonitorActionGroupResource) smsReceivers(data acceptance.TestData, count int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  dynamic "sms_receiver" {
+    for_each = range(%d)
+    content {
+      name         = "oncall-${sms_receiver.value}"
+      country_code = "1"
+      phone_number = format("555555%%04d", sms_receiver.value)
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, count)
+}
+
 /*
 @favoretti: Disabling this one, since it's written in such a way that it will never succeed in CI
 
@@ -723,6 +1026,62 @@ resource "azurerm_monitor_action_group" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) voiceReceiverCountryCode(data acceptance.TestData, countryCode string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  voice_receiver {
+    name         = "oncallmsg"
+    country_code = "%s"
+    phone_number = "2123456789"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, countryCode)
+}
+
+func (MonitorActionGroupResource) voiceAndEmailReceiver(data acceptance.TestData, emailAddress string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  email_receiver {
+    name                    = "sendtoadmin"
+    email_address           = "%[3]s"
+    use_common_alert_schema = false
+  }
+
+  voice_receiver {
+    name         = "oncallmsg"
+    country_code = "1"
+    phone_number = "2123456789"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, emailAddress)
+}
+
 func (MonitorActionGroupResource) logicAppReceiver(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -772,6 +1131,55 @@ SCHEMA
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) logicAppReceiverTriggerName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  logic_app_receiver {
+    name                    = "logicappaction"
+    resource_id             = azurerm_logic_app_workflow.test.id
+    trigger_name            = azurerm_logic_app_trigger_http_request.test.name
+    use_common_alert_schema = true
+  }
+}
+
+resource "azurerm_logic_app_workflow" "test" {
+  name                = "acctestLA-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_logic_app_trigger_http_request" "test" {
+  name         = "some-http-trigger"
+  logic_app_id = azurerm_logic_app_workflow.test.id
+
+  schema = <<SCHEMA
+{
+	"type": "object",
+	"properties": {
+		"hello": {
+			"type": "string"
+		}
+	}
+}
+SCHEMA
+
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
 func (MonitorActionGroupResource) azureFunctionReceiver(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -852,6 +1260,31 @@ resource "azurerm_monitor_action_group" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) armRoleReceiverRoleId(data acceptance.TestData, roleId string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  arm_role_receiver {
+    name                    = "Monitoring Reader"
+    role_id                 = "%s"
+    use_common_alert_schema = false
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, roleId)
+}
+
 func (MonitorActionGroupResource) eventHubReceiver(data acceptance.TestData, notFourPointOhBeta bool) string {
 	if notFourPointOhBeta {
 		return fmt.Sprintf(`
@@ -934,6 +1367,77 @@ resource "azurerm_monitor_action_group" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (MonitorActionGroupResource) eventHubReceiverExplicitIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_eventhub_namespace" "test" {
+  name                = "acceptanceTestEventHubNamespace-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "Standard"
+  capacity            = 1
+}
+
+resource "azurerm_eventhub" "test" {
+  name                = "acceptanceTestEventHub"
+  namespace_name      = azurerm_eventhub_namespace.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  partition_count     = 1
+  message_retention   = 1
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  event_hub_receiver {
+    name                    = "eventhub-test-action"
+    event_hub_name          = azurerm_eventhub.test.name
+    event_hub_namespace     = azurerm_eventhub.test.namespace_name
+    subscription_id         = data.azurerm_client_config.current.subscription_id
+    tenant_id               = data.azurerm_client_config.current.tenant_id
+    use_common_alert_schema = true
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (MonitorActionGroupResource) eventHubReceiverInvalidNamespace(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  short_name          = "acctestag"
+
+  event_hub_receiver {
+    name                = "eventhub-test-action"
+    event_hub_namespace = "1-acctesteventhubnamespace"
+    event_hub_name      = "acctesteventhub"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+}
+
 func (MonitorActionGroupResource) complete(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -998,7 +1502,7 @@ resource "azurerm_monitor_action_group" "test" {
 
   webhook_receiver {
     name        = "callmyapiaswell"
-    service_uri = "http://example.com/alert"
+    service_uri = "https://example.com/alert"
   }
 
   webhook_receiver {
@@ -1179,7 +1683,58 @@ func (t MonitorActionGroupResource) Exists(ctx context.Context, clients *clients
 	return utils.Bool(resp.Model != nil), nil
 }
 
-func (MonitorActionGroupResource) location(data acceptance.TestData) string {
+func (MonitorActionGroupResource) regenerateLogicAppAccessKey(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := workflows.ParseWorkflowID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	input := workflows.RegenerateActionParameter{
+		KeyType: pointer.To(workflows.KeyTypePrimary),
+	}
+	if _, err := client.Logic.WorkflowClient.RegenerateAccessKey(ctx, *id, input); err != nil {
+		return fmt.Errorf("regenerating the primary access key of %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func (MonitorActionGroupResource) logicAppCallbackUrlMatchesTrigger(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := actiongroupsapis.ParseActionGroupID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Monitor.ActionGroupsClient.ActionGroupsGet(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.LogicAppReceivers == nil || len(*resp.Model.Properties.LogicAppReceivers) != 1 {
+		return fmt.Errorf("expected %s to have a single Logic App receiver", *id)
+	}
+	receiver := (*resp.Model.Properties.LogicAppReceivers)[0]
+
+	workflowId, err := workflows.ParseWorkflowIDInsensitively(receiver.ResourceId)
+	if err != nil {
+		return err
+	}
+	triggerId := workflowtriggers.NewTriggerID(workflowId.SubscriptionId, workflowId.ResourceGroupName, workflowId.WorkflowName, "some-http-trigger")
+	callbackUrl, err := client.Logic.TriggersClient.ListCallbackUrl(ctx, triggerId)
+	if err != nil {
+		return fmt.Errorf("retrieving callback URL for %s: %+v", triggerId, err)
+	}
+	if callbackUrl.Model == nil || callbackUrl.Model.Value == nil {
+		return fmt.Errorf("retrieving callback URL for %s: `value` was nil", triggerId)
+	}
+
+	if receiver.CallbackUrl != *callbackUrl.Model.Value {
+		return fmt.Errorf("expected the callback URL of %s to be the current callback URL of %s", *id, triggerId)
+	}
+
+	return nil
+}
+
+func (MonitorActionGroupResource) location(data acceptance.TestData, location string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
@@ -1195,7 +1750,7 @@ resource "azurerm_monitor_action_group" "test" {
   resource_group_name = azurerm_resource_group.test.name
   short_name          = "acctestag"
   enabled             = false
-  location            = "swedencentral"
+  location            = "%s"
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, location)
 }
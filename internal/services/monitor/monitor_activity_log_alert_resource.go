@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -44,6 +45,8 @@ func resourceMonitorActivityLogAlert() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorActivityLogAlertCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -85,9 +88,19 @@ func resourceMonitorActivityLogAlert() *pluginsdk.Resource {
 							}, false),
 						},
 						"operation_name": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringIsNotEmpty,
+							Type:          pluginsdk.TypeString,
+							Optional:      true,
+							ValidateFunc:  validation.StringIsNotEmpty,
+							ConflictsWith: []string{"criteria.0.operation_names"},
+						},
+						"operation_names": {
+							Type:     pluginsdk.TypeList,
+							Optional: true,
+							Elem: &pluginsdk.Schema{
+								Type:         pluginsdk.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+							ConflictsWith: []string{"criteria.0.operation_name"},
 						},
 						"caller": {
 							Type:         pluginsdk.TypeString,
@@ -389,6 +402,38 @@ func resourceMonitorActivityLogAlert() *pluginsdk.Resource {
 	}
 }
 
+// monitorActivityLogAlertCustomizeDiff ensures the `resource_health` and `service_health` blocks are only used with their
+// matching `category`, since these conditions are only read back for that category and would otherwise always show a diff.
+func monitorActivityLogAlertCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	criteria := d.GetRawConfig().GetAttr("criteria")
+	if !criteria.IsKnown() || criteria.IsNull() {
+		return nil
+	}
+
+	for it := criteria.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		if !v.IsKnown() || v.IsNull() {
+			continue
+		}
+
+		category := v.GetAttr("category")
+		if !category.IsKnown() || category.IsNull() {
+			continue
+		}
+
+		for key, expected := range map[string]string{
+			"resource_health": "ResourceHealth",
+			"service_health":  "ServiceHealth",
+		} {
+			if block := v.GetAttr(key); block.IsKnown() && !block.IsNull() && block.LengthInt() > 0 && category.AsString() != expected {
+				return fmt.Errorf("`criteria.0.%s` can only be specified when `criteria.0.category` is `%s`, got %q", key, expected, category.AsString())
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceMonitorActivityLogAlertCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.ActivityLogAlertsClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
@@ -526,6 +571,12 @@ func expandMonitorActivityLogAlertCriteria(input []interface{}) activitylogalert
 		})
 	}
 
+	if operationNames := v["operation_names"].([]interface{}); len(operationNames) > 0 {
+		conditions = append(conditions, activitylogalertsapis.AlertRuleAnyOfOrLeafCondition{
+			AnyOf: expandAnyOfCondition(operationNames, "operationName"),
+		})
+	}
+
 	if caller := v["caller"].(string); caller != "" {
 		conditions = append(conditions, activitylogalertsapis.AlertRuleAnyOfOrLeafCondition{
 			Field:  utils.String("caller"),
@@ -820,6 +871,8 @@ func flattenMonitorActivityLogAlertCriteria(input activitylogalertsapis.AlertRul
 
 		if condition.Field != nil && condition.ContainsAny != nil && len(*condition.ContainsAny) > 0 {
 			switch strings.ToLower(*condition.Field) {
+			case "operationname":
+				result["operation_names"] = *condition.ContainsAny
 			case "resourceprovider":
 				result["resource_providers"] = *condition.ContainsAny
 			case "resourcetype":
@@ -844,6 +897,8 @@ func flattenMonitorActivityLogAlertCriteria(input activitylogalertsapis.AlertRul
 					values = append(values, *leafCondition.Equals)
 				}
 				switch strings.ToLower(*leafCondition.Field) {
+				case "operationname":
+					result["operation_names"] = values
 				case "resourceprovider":
 					result["resource_providers"] = values
 				case "resourcetype":
@@ -164,7 +164,7 @@ func (r AlertPrometheusRuleGroupResource) Arguments() map[string]*pluginsdk.Sche
 					"expression": {
 						Type:         pluginsdk.TypeString,
 						Required:     true,
-						ValidateFunc: validation.StringIsNotEmpty,
+						ValidateFunc: validation.StringIsNotWhiteSpace,
 					},
 
 					"for": {
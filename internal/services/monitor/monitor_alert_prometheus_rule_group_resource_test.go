@@ -3,6 +3,7 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -30,6 +31,17 @@ func TestAccAlertsManagementPrometheusRuleGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccAlertsManagementPrometheusRuleGroup_emptyExpression(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_alert_prometheus_rule_group", "test")
+	r := AlertPrometheusRuleGroupTestResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.emptyExpression(data),
+			ExpectError: regexp.MustCompile("to not be an empty string or whitespace"),
+		},
+	})
+}
+
 func TestAccAlertsManagementPrometheusRuleGroup_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_alert_prometheus_rule_group", "test")
 	r := AlertPrometheusRuleGroupTestResource{}
@@ -145,6 +157,29 @@ EOF
 `, template, data.RandomInteger, data.Locations.Primary)
 }
 
+func (r AlertPrometheusRuleGroupTestResource) emptyExpression(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_monitor_alert_prometheus_rule_group" "test" {
+  name                = "acctest-amprg-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = "%s"
+  scopes              = [azurerm_monitor_workspace.test.id]
+  rule {
+    expression = "  "
+    alert      = "Billing_Processing_Very_Slow"
+    severity   = 2
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
 func (r AlertPrometheusRuleGroupTestResource) requiresImport(data acceptance.TestData) string {
 	config := r.basic(data)
 	return fmt.Sprintf(`
@@ -1,9 +1,11 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/date"
@@ -48,6 +50,8 @@ func resourceMonitorAutoScaleSetting() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorAutoScaleSettingCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -355,7 +359,6 @@ func resourceMonitorAutoScaleSetting() *pluginsdk.Resource {
 									"minutes": {
 										Type:     pluginsdk.TypeList,
 										Required: true,
-										MaxItems: 1,
 										Elem: &pluginsdk.Schema{
 											Type:         pluginsdk.TypeInt,
 											ValidateFunc: validation.IntBetween(0, 59),
@@ -529,7 +532,7 @@ func resourceMonitorAutoScaleSettingRead(d *pluginsdk.ResourceData, meta interfa
 		}
 
 		if err = d.Set("predictive", flattenAzureRmMonitorAutoScaleSettingPredictive(props.PredictiveAutoscalePolicy)); err != nil {
-			return fmt.Errorf("setting `predictive_scale_mode` of %s: %+v", *id, err)
+			return fmt.Errorf("setting `predictive` of %s: %+v", *id, err)
 		}
 
 		notifications := flattenAzureRmMonitorAutoScaleSettingNotification(props.Notifications)
@@ -611,8 +614,11 @@ func expandAzureRmMonitorAutoScaleSettingProfile(input []interface{}) ([]autosca
 }
 
 func expandAzureRmMonitorAutoScaleSettingPredictive(input []interface{}) *autoscalesettings.PredictiveAutoscalePolicy {
+	// omission of the block means disabled, which needs to be sent explicitly so removing the block turns it off
 	if len(input) == 0 || input[0] == nil {
-		return nil
+		return &autoscalesettings.PredictiveAutoscalePolicy{
+			ScaleMode: autoscalesettings.PredictiveAutoscalePolicyScaleModeDisabled,
+		}
 	}
 
 	raw := input[0].(map[string]interface{})
@@ -1092,6 +1098,77 @@ func flattenAzureRmMonitorAutoScaleSettingRulesDimensions(dimensions *[]autoscal
 	return results
 }
 
+func monitorAutoScaleSettingCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	profiles := d.GetRawConfig().GetAttr("profile")
+	if !profiles.IsKnown() || profiles.IsNull() {
+		return nil
+	}
+
+	for profileIt := profiles.ElementIterator(); profileIt.Next(); {
+		profileKey, profile := profileIt.Element()
+		if !profile.IsKnown() || profile.IsNull() {
+			continue
+		}
+		rules := profile.GetAttr("rule")
+		if !rules.IsKnown() || rules.IsNull() {
+			continue
+		}
+
+		for ruleIt := rules.ElementIterator(); ruleIt.Next(); {
+			ruleKey, rule := ruleIt.Element()
+			if !rule.IsKnown() || rule.IsNull() {
+				continue
+			}
+			triggers := rule.GetAttr("metric_trigger")
+			if !triggers.IsKnown() || triggers.IsNull() {
+				continue
+			}
+
+			for triggerIt := triggers.ElementIterator(); triggerIt.Next(); {
+				_, trigger := triggerIt.Element()
+				if !trigger.IsKnown() || trigger.IsNull() {
+					continue
+				}
+
+				metricResourceId := trigger.GetAttr("metric_resource_id")
+				metricNamespace := trigger.GetAttr("metric_namespace")
+				if !metricResourceId.IsKnown() || metricResourceId.IsNull() || !metricNamespace.IsKnown() || metricNamespace.IsNull() {
+					continue
+				}
+
+				if err := validateAutoScaleSettingMetricNamespace(metricResourceId.AsString(), metricNamespace.AsString()); err != nil {
+					profileIndex, _ := profileKey.AsBigFloat().Int64()
+					ruleIndex, _ := ruleKey.AsBigFloat().Int64()
+					return fmt.Errorf("`profile.%d.rule.%d.metric_trigger.0`: %+v", profileIndex, ruleIndex, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateAutoScaleSettingMetricNamespace checks that a platform metric namespace (e.g. `microsoft.servicebus/namespaces`)
+// belongs to the resource type of `metric_resource_id` - which may differ from the `target_resource_id` being scaled.
+// Custom and guest metric namespaces (e.g. `azure.vm.windows.guestmetrics`) aren't resource types and so aren't checked.
+func validateAutoScaleSettingMetricNamespace(metricResourceId, metricNamespace string) error {
+	if !strings.Contains(metricNamespace, "/") {
+		return nil
+	}
+
+	resourceType := strings.ToLower(monitorScopeResourceType(metricResourceId))
+	if resourceType == "" {
+		return nil
+	}
+
+	namespace := strings.ToLower(metricNamespace)
+	if namespace == resourceType || strings.HasPrefix(namespace, resourceType+"/") {
+		return nil
+	}
+
+	return fmt.Errorf("the `metric_namespace` %q doesn't match the resource type %q of the `metric_resource_id` %q - when scaling on a metric from another resource `metric_namespace` must be the namespace of the resource emitting the metric, e.g. `microsoft.servicebus/namespaces` for the `ActiveMessages` metric of a Service Bus Namespace", metricNamespace, resourceType, metricResourceId)
+}
+
 func validateAutoScaleSettingsTimeZone() pluginsdk.SchemaValidateFunc {
 	// from https://docs.microsoft.com/en-us/rest/api/monitor/autoscalesettings/createorupdate#timewindow
 	timeZones := []string{
@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"testing"
+)
+
+func TestValidateAutoScaleSettingMetricNamespace(t *testing.T) {
+	cases := []struct {
+		MetricResourceId string
+		MetricNamespace  string
+		ShouldError      bool
+	}{
+		{
+			// the resource being scaled
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachineScaleSets/vmss1",
+			MetricNamespace:  "microsoft.compute/virtualmachinescalesets",
+		},
+		{
+			// another resource
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ServiceBus/namespaces/namespace1",
+			MetricNamespace:  "Microsoft.ServiceBus/namespaces",
+		},
+		{
+			// nested resource
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/queueServices/default",
+			MetricNamespace:  "microsoft.storage/storageaccounts/queueservices",
+		},
+		{
+			// sub-namespace of the resource type
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Insights/components/component1",
+			MetricNamespace:  "microsoft.insights/components/kusto",
+		},
+		{
+			// guest metrics aren't a resource type
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachineScaleSets/vmss1",
+			MetricNamespace:  "azure.vm.windows.guestmetrics",
+		},
+		{
+			// namespace of the scaled resource rather than the resource emitting the metric
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ServiceBus/namespaces/namespace1",
+			MetricNamespace:  "microsoft.compute/virtualmachinescalesets",
+			ShouldError:      true,
+		},
+		{
+			// parent of the resource type
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/queueServices/default",
+			MetricNamespace:  "microsoft.storage/storageaccounts",
+			ShouldError:      true,
+		},
+		{
+			// sharing a prefix isn't enough
+			MetricResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.ServiceBus/namespaces/namespace1",
+			MetricNamespace:  "microsoft.servicebus/namespacesx",
+			ShouldError:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q / %q", tc.MetricResourceId, tc.MetricNamespace)
+
+		err := validateAutoScaleSettingMetricNamespace(tc.MetricResourceId, tc.MetricNamespace)
+		if tc.ShouldError && err == nil {
+			t.Fatalf("expected an error for %q / %q but didn't get one", tc.MetricResourceId, tc.MetricNamespace)
+		}
+		if !tc.ShouldError && err != nil {
+			t.Fatalf("expected no error for %q / %q but got: %+v", tc.MetricResourceId, tc.MetricNamespace, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-10-01/autoscalesettings"
@@ -115,6 +116,14 @@ func TestAccMonitorAutoScaleSetting_predictiveUpdated(t *testing.T) {
 			),
 		},
 		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("predictive.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
 	})
 }
 
@@ -275,6 +284,24 @@ func TestAccMonitorAutoScaleSetting_recurrenceUpdate(t *testing.T) {
 	})
 }
 
+func TestAccMonitorAutoScaleSetting_recurrenceMultipleMinutes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_autoscale_setting", "test")
+	r := MonitorAutoScaleSettingResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.recurrenceMultipleMinutes(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("profile.0.recurrence.0.minutes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("profile.0.recurrence.0.minutes.0").HasValue("15"),
+				check.That(data.ResourceName).Key("profile.0.recurrence.0.minutes.1").HasValue("45"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorAutoScaleSetting_fixedDate(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_autoscale_setting", "test")
 	r := MonitorAutoScaleSettingResource{}
@@ -331,6 +358,35 @@ func TestAccMonitorAutoScaleSetting_multipleRulesDimensions(t *testing.T) {
 	})
 }
 
+func TestAccMonitorAutoScaleSetting_crossResourceMetric(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_autoscale_setting", "test")
+	r := MonitorAutoScaleSettingResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.crossResourceMetric(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("profile.0.rule.0.metric_trigger.0.metric_namespace").HasValue("microsoft.servicebus/namespaces"),
+				check.That(data.ResourceName).Key("profile.0.rule.0.metric_trigger.0.dimensions.0.name").HasValue("EntityName"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorAutoScaleSetting_crossResourceMetricNamespaceMismatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_autoscale_setting", "test")
+	r := MonitorAutoScaleSettingResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.crossResourceMetricNamespaceMismatch(data),
+			ExpectError: regexp.MustCompile("doesn't match the resource type"),
+		},
+	})
+}
+
 func (t MonitorAutoScaleSettingResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := autoscalesettings.ParseAutoScaleSettingID(state.ID)
 	if err != nil {
@@ -875,6 +931,43 @@ resource "azurerm_monitor_autoscale_setting" "test" {
 `, template, data.RandomInteger)
 }
 
+func (MonitorAutoScaleSettingResource) recurrenceMultipleMinutes(data acceptance.TestData) string {
+	template := MonitorAutoScaleSettingResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_autoscale_setting" "test" {
+  name                = "acctestautoscale-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.test.id
+
+  profile {
+    name = "recurrence"
+
+    capacity {
+      default = 1
+      minimum = 1
+      maximum = 10
+    }
+
+    recurrence {
+      timezone = "Pacific Standard Time"
+
+      days = [
+        "Monday",
+        "Tuesday",
+        "Wednesday",
+      ]
+
+      hours   = [20]
+      minutes = [15, 45]
+    }
+  }
+}
+`, template, data.RandomInteger)
+}
+
 func (MonitorAutoScaleSettingResource) fixedDate(data acceptance.TestData) string {
 	template := MonitorAutoScaleSettingResource{}.template(data)
 	return fmt.Sprintf(`
@@ -1056,6 +1149,115 @@ resource "azurerm_monitor_autoscale_setting" "test" {
 `, template, data.RandomInteger)
 }
 
+func (MonitorAutoScaleSettingResource) crossResourceMetric(data acceptance.TestData) string {
+	template := MonitorAutoScaleSettingResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_servicebus_namespace" "test" {
+  name                = "acctestsbn-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "Standard"
+}
+
+resource "azurerm_servicebus_queue" "test" {
+  name         = "acctestsbq-%d"
+  namespace_id = azurerm_servicebus_namespace.test.id
+}
+
+resource "azurerm_monitor_autoscale_setting" "test" {
+  name                = "acctestautoscale-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.test.id
+
+  profile {
+    name = "queueLength"
+
+    capacity {
+      default = 1
+      minimum = 1
+      maximum = 10
+    }
+
+    rule {
+      metric_trigger {
+        metric_name              = "ActiveMessages"
+        metric_namespace         = "microsoft.servicebus/namespaces"
+        metric_resource_id       = azurerm_servicebus_namespace.test.id
+        time_grain               = "PT1M"
+        statistic                = "Average"
+        time_window              = "PT5M"
+        time_aggregation         = "Average"
+        operator                 = "GreaterThan"
+        threshold                = 100
+        divide_by_instance_count = true
+
+        dimensions {
+          name     = "EntityName"
+          operator = "Equals"
+          values   = [azurerm_servicebus_queue.test.name]
+        }
+      }
+
+      scale_action {
+        direction = "Increase"
+        type      = "ChangeCount"
+        value     = 1
+        cooldown  = "PT5M"
+      }
+    }
+  }
+}
+`, template, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (MonitorAutoScaleSettingResource) crossResourceMetricNamespaceMismatch(data acceptance.TestData) string {
+	template := MonitorAutoScaleSettingResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_autoscale_setting" "test" {
+  name                = "acctestautoscale-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  target_resource_id  = azurerm_linux_virtual_machine_scale_set.test.id
+
+  profile {
+    name = "queueLength"
+
+    capacity {
+      default = 1
+      minimum = 1
+      maximum = 10
+    }
+
+    rule {
+      metric_trigger {
+        metric_name        = "ActiveMessages"
+        metric_namespace   = "microsoft.compute/virtualmachinescalesets"
+        metric_resource_id = "/subscriptions/%s/resourceGroups/${azurerm_resource_group.test.name}/providers/Microsoft.ServiceBus/namespaces/acctestsbn-%d"
+        time_grain         = "PT1M"
+        statistic          = "Average"
+        time_window        = "PT5M"
+        time_aggregation   = "Average"
+        operator           = "GreaterThan"
+        threshold          = 100
+      }
+
+      scale_action {
+        direction = "Increase"
+        type      = "ChangeCount"
+        value     = 1
+        cooldown  = "PT5M"
+      }
+    }
+  }
+}
+`, template, data.RandomInteger, data.Client().SubscriptionID, data.RandomInteger)
+}
+
 func (MonitorAutoScaleSettingResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
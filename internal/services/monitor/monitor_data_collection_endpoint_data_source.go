@@ -92,7 +92,7 @@ func (d DataCollectionEndpointDataSource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("retrieving %s: %+v", id, err)
 			}
 
-			var enablePublicNetWorkAccess bool
+			enablePublicNetWorkAccess := true
 			var description, kind, location, configurationAccessEndpoint, logsIngestionEndpoint string
 			var tag map[string]interface{}
 			if model := resp.Model; model != nil {
@@ -156,7 +156,7 @@ func (r DataCollectionEndpointResource) Read() sdk.ResourceFunc {
 				}
 				return fmt.Errorf("retrieving %s: %+v", *id, err)
 			}
-			var enablePublicNetWorkAccess bool
+			enablePublicNetWorkAccess := true
 			var description, kind, location, configurationAccessEndpoint, logsIngestionEndpoint string
 			var tag map[string]interface{}
 			if model := resp.Model; model != nil {
@@ -205,6 +205,23 @@ func (r DataCollectionEndpointResource) Update() sdk.ResourceFunc {
 
 			metadata.Logger.Infof("updating %s..", *id)
 			client := metadata.Client.Monitor.DataCollectionEndpointsClient
+
+			var state DataCollectionEndpoint
+			if err := metadata.Decode(&state); err != nil {
+				return err
+			}
+
+			// tags can be updated using a PATCH, which leaves the rest of the endpoint untouched
+			if !metadata.ResourceData.HasChangesExcept("tags") {
+				payload := datacollectionendpoints.ResourceForUpdate{
+					Tags: tags.Expand(state.Tags),
+				}
+				if _, err := client.Update(ctx, *id, payload); err != nil {
+					return fmt.Errorf("updating tags for %s: %+v", *id, err)
+				}
+				return nil
+			}
+
 			resp, err := client.Get(ctx, *id)
 			if err != nil {
 				return fmt.Errorf("retrieving %s: %+v", *id, err)
@@ -217,11 +234,6 @@ func (r DataCollectionEndpointResource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("unexpected null properties of %s", *id)
 			}
 
-			var state DataCollectionEndpoint
-			if err := metadata.Decode(&state); err != nil {
-				return err
-			}
-
 			if metadata.ResourceData.HasChange("description") {
 				existing.Properties.Description = utils.String(state.Description)
 			}
@@ -230,7 +242,7 @@ func (r DataCollectionEndpointResource) Update() sdk.ResourceFunc {
 				existing.Kind = expandDataCollectionEndpointKind(state.Kind)
 			}
 
-			if metadata.ResourceData.HasChange("public_network_access") {
+			if metadata.ResourceData.HasChange("public_network_access_enabled") {
 				existing.Properties.NetworkAcls = &datacollectionendpoints.NetworkRuleSet{
 					PublicNetworkAccess: expandDataCollectionEndpointPublicNetworkAccess(state.EnablePublicNetworkAccess),
 				}
@@ -305,8 +317,9 @@ func flattenDataCollectionEndpointDescription(input *string) string {
 }
 
 func flattenDataCollectionEndpointPublicNetworkAccess(input *datacollectionendpoints.KnownPublicNetworkAccessOptions) bool {
+	// the API defaults to `Enabled` when `publicNetworkAccess` is omitted
 	if input == nil {
-		return false
+		return true
 	}
 	var result bool
 	if *input == datacollectionendpoints.KnownPublicNetworkAccessOptionsEnabled {
@@ -78,6 +78,7 @@ func TestAccMonitorDataCollectionEndpoint_update(t *testing.T) {
 			Config: r.complete(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_network_access_enabled").HasValue("false"),
 			),
 		},
 		data.ImportStep(),
@@ -85,6 +86,46 @@ func TestAccMonitorDataCollectionEndpoint_update(t *testing.T) {
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_network_access_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorDataCollectionEndpoint_updateTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_endpoint", "test")
+	r := MonitorDataCollectionEndpointResource{}
+	association := "azurerm_monitor_data_collection_rule_association.test"
+
+	// the ingestion endpoint is unique to each endpoint, so it changes if the endpoint is recreated
+	var logsIngestionEndpoint string
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.tagsWithAssociation(data, "staging"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("staging"),
+				func(s *acceptance.State) error {
+					logsIngestionEndpoint = s.RootModule().Resources[data.ResourceName].Primary.Attributes["logs_ingestion_endpoint"]
+					return nil
+				},
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.tagsWithAssociation(data, "production"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("production"),
+				func(s *acceptance.State) error {
+					if actual := s.RootModule().Resources[data.ResourceName].Primary.Attributes["logs_ingestion_endpoint"]; actual != logsIngestionEndpoint {
+						return fmt.Errorf("expected the Data Collection Endpoint to be updated in-place, but `logs_ingestion_endpoint` changed from %q to %q", logsIngestionEndpoint, actual)
+					}
+					return nil
+				},
+				check.That(association).ExistsInAzure(MonitorDataCollectionRuleAssociationResource{}),
 			),
 		},
 		data.ImportStep(),
@@ -100,6 +141,7 @@ func TestAccMonitorDataCollectionEndpoint_complete(t *testing.T) {
 			Config: r.complete(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_network_access_enabled").HasValue("false"),
 			),
 		},
 		data.ImportStep(),
@@ -121,7 +163,7 @@ func (r MonitorDataCollectionEndpointResource) complete(data acceptance.TestData
 	return fmt.Sprintf(`
 %[1]s
 resource "azurerm_monitor_data_collection_endpoint" "test" {
-  name                          = "acctestmdce-%[2]d"
+  name                          = "acctestmdcr-%[2]d"
   resource_group_name           = azurerm_resource_group.test.name
   location                      = azurerm_resource_group.test.location
   kind                          = "Windows"
@@ -134,6 +176,78 @@ resource "azurerm_monitor_data_collection_endpoint" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r MonitorDataCollectionEndpointResource) tagsWithAssociation(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestnw-%[2]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.2.0/24"]
+}
+
+resource "azurerm_network_interface" "test" {
+  name                = "acctestnic-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  ip_configuration {
+    name                          = "internal"
+    subnet_id                     = azurerm_subnet.test.id
+    private_ip_address_allocation = "Dynamic"
+  }
+}
+
+resource "azurerm_linux_virtual_machine" "test" {
+  name                            = "acctestvm-%[2]d"
+  resource_group_name             = azurerm_resource_group.test.name
+  location                        = azurerm_resource_group.test.location
+  size                            = "Standard_B1ls"
+  admin_username                  = "adminuser"
+  admin_password                  = "test-Password@7890"
+  disable_password_authentication = false
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_monitor_data_collection_endpoint" "test" {
+  name                = "acctestmdce-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  tags = {
+    environment = "%[3]s"
+  }
+}
+
+resource "azurerm_monitor_data_collection_rule_association" "test" {
+  target_resource_id          = azurerm_linux_virtual_machine.test.id
+  data_collection_endpoint_id = azurerm_monitor_data_collection_endpoint.test.id
+}
+`, r.template(data), data.RandomInteger, environment)
+}
+
 func (r MonitorDataCollectionEndpointResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -3,6 +3,7 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -121,6 +122,7 @@ func (r DataCollectionRuleAssociationResource) Create() sdk.ResourceFunc {
 			if _, err := client.Create(ctx, id, input); err != nil {
 				return fmt.Errorf("creating %s: %+v", id, err)
 			}
+			invalidateDataCollectionRuleAssociationsForRule(metadata, model.DataCollectionRuleId)
 
 			metadata.SetID(id)
 			return nil
@@ -138,23 +140,73 @@ func (r DataCollectionRuleAssociationResource) Read() sdk.ResourceFunc {
 				return err
 			}
 
-			metadata.Logger.Infof("retrieving %s", *id)
-			resp, err := client.Get(ctx, *id)
-			if err != nil {
-				if response.WasNotFound(resp.HttpResponse) {
-					metadata.Logger.Infof("%s was not found - removing from state!", *id)
-					return metadata.MarkAsGone(id)
+			var ruleId *datacollectionruleassociations.DataCollectionRuleId
+			if v := metadata.ResourceData.Get("data_collection_rule_id").(string); v != "" {
+				if parsed, err := datacollectionruleassociations.ParseDataCollectionRuleIDInsensitively(v); err == nil {
+					ruleId = parsed
 				}
-				return fmt.Errorf("retrieving %s: %+v", *id, err)
 			}
 
+			get := func(ctx context.Context, id datacollectionruleassociations.ScopedDataCollectionRuleAssociationId) (*datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, error) {
+				metadata.Logger.Infof("retrieving %s", id)
+				resp, err := client.Get(ctx, id)
+				if err != nil {
+					if response.WasNotFound(resp.HttpResponse) {
+						return nil, nil
+					}
+					return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+				}
+				if resp.Model == nil {
+					return &datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{}, nil
+				}
+				return resp.Model, nil
+			}
+
+			association, found, err := retrieveDataCollectionRuleAssociation(ctx, metadata.Logger, *id, ruleId, metadata.Client.Monitor.DataCollectionRuleAssociationsForRule, get)
+			if err != nil {
+				return err
+			}
+			if association == nil {
+				metadata.Logger.Infof("%s was not found - removing from state!", *id)
+				return metadata.MarkAsGone(id)
+			}
+			properties := association.Properties
+
 			var description, dataCollectionEndpointId, dataCollectionRuleId string
+			if properties != nil {
+				dataCollectionEndpointId = flattenStringPtr(properties.DataCollectionEndpointId)
+				dataCollectionRuleId = flattenStringPtr(properties.DataCollectionRuleId)
+				description = flattenStringPtr(properties.Description)
+			}
 
-			if model := resp.Model; model != nil {
-				if prop := model.Properties; prop != nil {
-					dataCollectionEndpointId = flattenStringPtr(prop.DataCollectionEndpointId)
-					dataCollectionRuleId = flattenStringPtr(prop.DataCollectionRuleId)
-					description = flattenStringPtr(prop.Description)
+			// the association isn't removed when the Data Collection Rule or Endpoint it references is deleted, so it
+			// can't be removed from the state either (since it'd then fail to be created as it already exists) - instead
+			// the dangling reference is cleared, so that the plan updates the association to reference the rule/endpoint
+			// once it exists again. Since many associations commonly reference the same rule/endpoint, whether these
+			// exist is cached, and the rule is known to exist when the association was found in its list of associations
+			if dataCollectionRuleId != "" && !found {
+				if ruleId, err := datacollectionrules.ParseDataCollectionRuleIDInsensitively(dataCollectionRuleId); err == nil {
+					exists, err := metadata.Client.Monitor.DataCollectionRuleExists(ctx, *ruleId)
+					if err != nil {
+						// e.g. a lack of permissions to read the referenced resource, which doesn't mean it's gone
+						metadata.Logger.Warnf("retrieving %s referenced by %s: %+v", *ruleId, *id, err)
+					} else if !exists {
+						metadata.Logger.Warnf("%s referenced by %s was not found - clearing the reference", *ruleId, *id)
+						dataCollectionRuleId = ""
+					}
+				}
+			}
+
+			if dataCollectionEndpointId != "" {
+				if endpointId, err := datacollectionendpoints.ParseDataCollectionEndpointIDInsensitively(dataCollectionEndpointId); err == nil {
+					exists, err := metadata.Client.Monitor.DataCollectionEndpointExists(ctx, *endpointId)
+					if err != nil {
+						// e.g. a lack of permissions to read the referenced resource, which doesn't mean it's gone
+						metadata.Logger.Warnf("retrieving %s referenced by %s: %+v", *endpointId, *id, err)
+					} else if !exists {
+						metadata.Logger.Warnf("%s referenced by %s was not found - clearing the reference", *endpointId, *id)
+						dataCollectionEndpointId = ""
+					}
 				}
 			}
 
@@ -220,6 +272,9 @@ func (r DataCollectionRuleAssociationResource) Update() sdk.ResourceFunc {
 			if _, err := client.Create(ctx, *id, *existing); err != nil {
 				return fmt.Errorf("updating %s: %+v", *id, err)
 			}
+			oldRuleId, newRuleId := metadata.ResourceData.GetChange("data_collection_rule_id")
+			invalidateDataCollectionRuleAssociationsForRule(metadata, oldRuleId.(string))
+			invalidateDataCollectionRuleAssociationsForRule(metadata, newRuleId.(string))
 			return nil
 		},
 		Timeout: 30 * time.Minute,
@@ -240,8 +295,54 @@ func (r DataCollectionRuleAssociationResource) Delete() sdk.ResourceFunc {
 			if err != nil && !response.WasNotFound(resp.HttpResponse) {
 				return fmt.Errorf("deleting %s: %+v", *id, err)
 			}
+			invalidateDataCollectionRuleAssociationsForRule(metadata, metadata.ResourceData.Get("data_collection_rule_id").(string))
 			return nil
 		},
 		Timeout: 30 * time.Minute,
 	}
 }
+
+// retrieveDataCollectionRuleAssociation returns the specified association, or nil when it doesn't exist. Refreshing the
+// many associations which commonly reference a single Data Collection Rule is slow when each is retrieved individually,
+// so when the rule is known its associations are listed (once per Terraform operation) instead - falling back to
+// retrieving the association individually when the rule doesn't exist or can't be listed, or when the association isn't
+// in its list (e.g. as it's been re-pointed). The returned bool is true when the association was found in the list of
+// the rule, which means the rule exists.
+func retrieveDataCollectionRuleAssociation(
+	ctx context.Context,
+	logger sdk.Logger,
+	id datacollectionruleassociations.ScopedDataCollectionRuleAssociationId,
+	ruleId *datacollectionruleassociations.DataCollectionRuleId,
+	listForRule func(context.Context, datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error),
+	get func(context.Context, datacollectionruleassociations.ScopedDataCollectionRuleAssociationId) (*datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, error),
+) (*datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+	if ruleId != nil {
+		associations, exists, err := listForRule(ctx, *ruleId)
+		switch {
+		case err != nil:
+			logger.Warnf("%+v - retrieving %s individually", err, id)
+		case !exists:
+			logger.Warnf("%s referenced by %s was not found - retrieving %s individually", *ruleId, id, id)
+		default:
+			for _, association := range associations {
+				if association.Id != nil && strings.EqualFold(*association.Id, id.ID()) {
+					return &association, true, nil
+				}
+			}
+		}
+	}
+
+	association, err := get(ctx, id)
+	return association, false, err
+}
+
+// invalidateDataCollectionRuleAssociationsForRule ensures associations which have been changed are retrieved again rather
+// than read from the cached associations of the Data Collection Rule
+func invalidateDataCollectionRuleAssociationsForRule(metadata sdk.ResourceMetaData, dataCollectionRuleId string) {
+	if dataCollectionRuleId == "" {
+		return
+	}
+	if ruleId, err := datacollectionruleassociations.ParseDataCollectionRuleIDInsensitively(dataCollectionRuleId); err == nil {
+		metadata.Client.Monitor.InvalidateDataCollectionRuleAssociationsForRule(*ruleId)
+	}
+}
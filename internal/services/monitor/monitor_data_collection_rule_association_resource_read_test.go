@@ -0,0 +1,125 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestRetrieveDataCollectionRuleAssociation(t *testing.T) {
+	id := datacollectionruleassociations.NewScopedDataCollectionRuleAssociationID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1", "association1")
+	ruleId := datacollectionruleassociations.NewDataCollectionRuleID("00000000-0000-0000-0000-000000000000", "group1", "rule1")
+
+	listed := datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{
+		Id: utils.String(id.ID()),
+		Properties: &datacollectionruleassociations.DataCollectionRuleAssociation{
+			Description: utils.String("listed"),
+		},
+	}
+	other := datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{
+		Id: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm2/providers/Microsoft.Insights/dataCollectionRuleAssociations/association1"),
+	}
+	retrieved := datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{
+		Id: utils.String(id.ID()),
+		Properties: &datacollectionruleassociations.DataCollectionRuleAssociation{
+			Description: utils.String("retrieved"),
+		},
+	}
+
+	cases := []struct {
+		Name                string
+		RuleId              *datacollectionruleassociations.DataCollectionRuleId
+		Listed              []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource
+		RuleExists          bool
+		ListErr             error
+		Retrieved           *datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource
+		ExpectedDescription string
+		ExpectedFoundInRule bool
+		ExpectedGets        int
+		ExpectedNotFound    bool
+	}{
+		{
+			Name:                "found in the list of the rule",
+			RuleId:              &ruleId,
+			Listed:              []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{other, listed},
+			RuleExists:          true,
+			ExpectedDescription: "listed",
+			ExpectedFoundInRule: true,
+		},
+		{
+			Name:                "rule unknown",
+			Retrieved:           &retrieved,
+			ExpectedDescription: "retrieved",
+			ExpectedGets:        1,
+		},
+		{
+			Name:                "not in the list of the rule",
+			RuleId:              &ruleId,
+			Listed:              []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{other},
+			RuleExists:          true,
+			Retrieved:           &retrieved,
+			ExpectedDescription: "retrieved",
+			ExpectedGets:        1,
+		},
+		{
+			Name:                "rule not found",
+			RuleId:              &ruleId,
+			Retrieved:           &retrieved,
+			ExpectedDescription: "retrieved",
+			ExpectedGets:        1,
+		},
+		{
+			Name:                "listing fails",
+			RuleId:              &ruleId,
+			ListErr:             fmt.Errorf("listing associations: forbidden"),
+			Retrieved:           &retrieved,
+			ExpectedDescription: "retrieved",
+			ExpectedGets:        1,
+		},
+		{
+			Name:             "association not found",
+			RuleId:           &ruleId,
+			Listed:           []datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{other},
+			RuleExists:       true,
+			ExpectedGets:     1,
+			ExpectedNotFound: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			list := func(_ context.Context, _ datacollectionruleassociations.DataCollectionRuleId) ([]datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, bool, error) {
+				return tc.Listed, tc.RuleExists, tc.ListErr
+			}
+			gets := 0
+			get := func(_ context.Context, _ datacollectionruleassociations.ScopedDataCollectionRuleAssociationId) (*datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource, error) {
+				gets++
+				return tc.Retrieved, nil
+			}
+
+			actual, foundInRule, err := retrieveDataCollectionRuleAssociation(context.TODO(), sdk.NullLogger{}, id, tc.RuleId, list, get)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if gets != tc.ExpectedGets {
+				t.Fatalf("expected %d individual retrievals but got %d", tc.ExpectedGets, gets)
+			}
+			if foundInRule != tc.ExpectedFoundInRule {
+				t.Fatalf("expected found in the list of the rule to be %t but got %t", tc.ExpectedFoundInRule, foundInRule)
+			}
+			if tc.ExpectedNotFound {
+				if actual != nil {
+					t.Fatalf("expected the association not to be found but got %+v", actual)
+				}
+				return
+			}
+			if actual == nil || actual.Properties == nil || *actual.Properties.Description != tc.ExpectedDescription {
+				t.Fatalf("expected the %q association but got %+v", tc.ExpectedDescription, actual)
+			}
+		})
+	}
+}
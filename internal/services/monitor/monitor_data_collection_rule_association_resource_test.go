@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionrules"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -128,6 +129,31 @@ func TestAccMonitorDataCollectionRuleAssociation_updateDataCollectionRuleId(t *t
 	})
 }
 
+func TestAccMonitorDataCollectionRuleAssociation_dataCollectionRuleDeleted(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule_association", "test")
+	r := MonitorDataCollectionRuleAssociationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.deleteDataCollectionRule, "azurerm_monitor_data_collection_rule.test"),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			// the association is kept and updated to reference the recreated Data Collection Rule
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_collection_rule_id").MatchesOtherKey(check.That("azurerm_monitor_data_collection_rule.test").Key("id")),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorDataCollectionRuleAssociation_complete(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule_association", "test")
 	r := MonitorDataCollectionRuleAssociationResource{}
@@ -143,6 +169,19 @@ func TestAccMonitorDataCollectionRuleAssociation_complete(t *testing.T) {
 	})
 }
 
+func (r MonitorDataCollectionRuleAssociationResource) deleteDataCollectionRule(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := datacollectionrules.ParseDataCollectionRuleID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Monitor.DataCollectionRulesClient.Delete(ctx, *id); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
 func (r MonitorDataCollectionRuleAssociationResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionrules"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/storageaccounts"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
@@ -195,6 +196,8 @@ type StreamDeclarationColumn struct {
 
 type DataCollectionRuleResource struct{}
 
+var _ sdk.ResourceWithCustomizeDiff = DataCollectionRuleResource{}
+
 func (r DataCollectionRuleResource) Arguments() map[string]*pluginsdk.Schema {
 	return map[string]*pluginsdk.Schema{
 		"name": {
@@ -921,6 +924,118 @@ func (r DataCollectionRuleResource) ModelObject() interface{} {
 	return &DataCollectionRule{}
 }
 
+func (r DataCollectionRuleResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			// the direct destinations are only accepted by the API for rules of kind `AgentDirectToStore`
+			if kind := diff.Get("kind").(string); kind != "AgentDirectToStore" {
+				for _, key := range []string{"event_hub_direct", "storage_blob_direct", "storage_table_direct"} {
+					if v, ok := diff.GetOk("destinations.0." + key); ok && len(v.([]interface{})) > 0 {
+						return fmt.Errorf("`destinations.0.%s` can only be specified when `kind` is `AgentDirectToStore`, got %q", key, kind)
+					}
+				}
+			}
+
+			// a rule only has a single `kind`, rules collecting from both Linux and Windows machines must leave `kind` unset
+			incompatibleDataSources := map[string]string{
+				"Linux":   "windows_event_log",
+				"Windows": "syslog",
+			}
+			if kind := diff.Get("kind").(string); incompatibleDataSources[kind] != "" {
+				key := incompatibleDataSources[kind]
+				if v, ok := diff.GetOk("data_sources.0." + key); ok && len(v.([]interface{})) > 0 {
+					return fmt.Errorf("`data_sources.0.%s` cannot be specified when `kind` is `%s` - to collect data from both Linux and Windows machines in a single rule, leave `kind` unset", key, kind)
+				}
+			}
+
+			// ingesting from Event Hubs goes through a Data Collection Endpoint, the endpoint may not be known until apply
+			if v, ok := diff.GetOk("data_sources.0.data_import.0.event_hub_data_source"); ok && len(v.([]interface{})) > 0 {
+				if diff.GetRawConfig().GetAttr("data_collection_endpoint_id").IsNull() {
+					return fmt.Errorf("`data_collection_endpoint_id` must be specified when `data_sources.0.data_import.0.event_hub_data_source` is specified")
+				}
+			}
+
+			return validateDataCollectionRuleDataFlows(diff.GetRawConfig().GetAttr("data_flow"))
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// validateDataCollectionRuleDataFlows ensures that no two `data_flow` blocks send the same input stream to the same
+// output stream of the same destination using the same transform. Multiple data flows may share an input stream, output
+// stream and destination (for example to apply disjoint transforms), but duplicate flows would ingest the same data twice
+// into the same table. Data flows containing values which aren't known yet are skipped.
+func validateDataCollectionRuleDataFlows(dataFlows cty.Value) error {
+	if !dataFlows.IsKnown() || dataFlows.IsNull() {
+		return nil
+	}
+
+	knownStrings := func(input cty.Value) ([]string, bool) {
+		if !input.IsWhollyKnown() || input.IsNull() {
+			return nil, false
+		}
+		result := make([]string, 0)
+		for it := input.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			if v.IsNull() {
+				continue
+			}
+			result = append(result, v.AsString())
+		}
+		return result, true
+	}
+
+	seen := make(map[string]int)
+	for it := dataFlows.ElementIterator(); it.Next(); {
+		k, dataFlow := it.Element()
+		index, _ := k.AsBigFloat().Int64()
+		if !dataFlow.IsKnown() || dataFlow.IsNull() {
+			continue
+		}
+
+		streams, ok := knownStrings(dataFlow.GetAttr("streams"))
+		if !ok {
+			continue
+		}
+		destinations, ok := knownStrings(dataFlow.GetAttr("destinations"))
+		if !ok {
+			continue
+		}
+		outputStream := dataFlow.GetAttr("output_stream")
+		if !outputStream.IsKnown() {
+			continue
+		}
+		transformKql := dataFlow.GetAttr("transform_kql")
+		if !transformKql.IsKnown() {
+			continue
+		}
+		transform := ""
+		if !transformKql.IsNull() {
+			transform = transformKql.AsString()
+		}
+
+		for _, stream := range streams {
+			// when `output_stream` is omitted the data is sent to the table of the input stream
+			output := stream
+			if !outputStream.IsNull() {
+				output = outputStream.AsString()
+			}
+
+			for _, destination := range destinations {
+				key := fmt.Sprintf("%s|%s|%s|%s", stream, output, destination, transform)
+				if previous, exists := seen[key]; exists && previous != int(index) {
+					return fmt.Errorf("`data_flow.%d` and `data_flow.%d` both send the input stream %q to the output stream %q of the destination %q using the same `transform_kql` - data flows sharing an input stream must use a different `output_stream`, `destinations` or `transform_kql`", previous, index, stream, output, destination)
+				}
+				seen[key] = int(index)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (r DataCollectionRuleResource) Create() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
@@ -1003,6 +1118,11 @@ func (r DataCollectionRuleResource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("retrieving %s: %+v", *id, err)
 			}
 
+			var existing DataCollectionRule
+			if err := metadata.Decode(&existing); err != nil {
+				return err
+			}
+
 			var dataCollectionEndpointId, description, immutableId, kind, location string
 			var tag map[string]interface{}
 			var dataFlows []DataFlow
@@ -1031,7 +1151,7 @@ func (r DataCollectionRuleResource) Read() sdk.ResourceFunc {
 					dataSources = flattenDataCollectionRuleDataSources(prop.DataSources)
 					destinations = flattenDataCollectionRuleDestinations(prop.Destinations)
 					immutableId = flattenStringPtr(prop.ImmutableId)
-					streamDeclaration = flattenDataCollectionRuleStreamDeclarations(prop.StreamDeclarations)
+					streamDeclaration = orderDataCollectionRuleStreamDeclarationColumns(existing.StreamDeclaration, flattenDataCollectionRuleStreamDeclarations(prop.StreamDeclarations))
 				}
 			}
 
@@ -2178,3 +2298,43 @@ func flattenDataCollectionRuleStreamDeclarations(input *map[string]datacollectio
 
 	return result
 }
+
+// orderDataCollectionRuleStreamDeclarationColumns sorts the columns of each stream declaration returned from the API
+// into the order they're defined in the existing state (matched on `name`), so that the API returning the columns in a
+// different order doesn't show up as a diff. Columns which aren't known yet are appended in API order, which is also
+// the order used when importing.
+func orderDataCollectionRuleStreamDeclarationColumns(existing []StreamDeclaration, flattened []StreamDeclaration) []StreamDeclaration {
+	existingColumns := make(map[string][]StreamDeclarationColumn)
+	for _, v := range existing {
+		existingColumns[v.StreamName] = v.Column
+	}
+
+	for i, stream := range flattened {
+		columns, ok := existingColumns[stream.StreamName]
+		if !ok {
+			continue
+		}
+
+		byName := make(map[string]StreamDeclarationColumn)
+		for _, column := range stream.Column {
+			byName[column.Name] = column
+		}
+
+		ordered := make([]StreamDeclarationColumn, 0, len(stream.Column))
+		for _, column := range columns {
+			if v, ok := byName[column.Name]; ok {
+				ordered = append(ordered, v)
+				delete(byName, column.Name)
+			}
+		}
+		for _, column := range stream.Column {
+			if _, ok := byName[column.Name]; ok {
+				ordered = append(ordered, column)
+			}
+		}
+
+		flattened[i].Column = ordered
+	}
+
+	return flattened
+}
@@ -3,6 +3,7 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -62,6 +63,72 @@ func TestAccMonitorDataCollectionRule_kindDirectToStore(t *testing.T) {
 	})
 }
 
+func TestAccMonitorDataCollectionRule_directDestinationWithoutDirectToStoreKind(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.directDestinationWithoutDirectToStoreKind(data),
+			ExpectError: regexp.MustCompile("`destinations.0.event_hub_direct` can only be specified when `kind` is `AgentDirectToStore`"),
+		},
+	})
+}
+
+func TestAccMonitorDataCollectionRule_windowsEventLogWithLinuxKind(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.windowsEventLogWithLinuxKind(data),
+			ExpectError: regexp.MustCompile("`data_sources.0.windows_event_log` cannot be specified when `kind` is `Linux`"),
+		},
+	})
+}
+
+func TestAccMonitorDataCollectionRule_dataImportWithoutEndpoint(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.dataImportWithoutEndpoint(data),
+			ExpectError: regexp.MustCompile("`data_collection_endpoint_id` must be specified"),
+		},
+	})
+}
+
+func TestAccMonitorDataCollectionRule_multipleDataFlowsSharingStream(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.multipleDataFlowsSharingStream(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_flow.#").HasValue("2"),
+				check.That(data.ResourceName).Key("data_flow.0.transform_kql").HasValue("source | where SeverityLevel == \"err\""),
+				check.That(data.ResourceName).Key("data_flow.1.transform_kql").HasValue("source | where SeverityLevel != \"err\""),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorDataCollectionRule_conflictingDataFlows(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.conflictingDataFlows(data),
+			ExpectError: regexp.MustCompile("`data_flow.0` and `data_flow.1` both send the input stream \"Microsoft-Syslog\""),
+		},
+	})
+}
+
 func TestAccMonitorDataCollectionRule_identity(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
 	r := MonitorDataCollectionRuleResource{}
@@ -71,6 +138,9 @@ func TestAccMonitorDataCollectionRule_identity(t *testing.T) {
 			Config: r.systemAssigned(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identity.0.type").HasValue("SystemAssigned"),
+				check.That(data.ResourceName).Key("identity.0.principal_id").IsUUID(),
+				check.That(data.ResourceName).Key("identity.0.tenant_id").IsUUID(),
 			),
 		},
 		data.ImportStep(),
@@ -78,6 +148,16 @@ func TestAccMonitorDataCollectionRule_identity(t *testing.T) {
 			Config: r.userAssigned(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identity.0.type").HasValue("UserAssigned"),
+				check.That(data.ResourceName).Key("identity.0.identity_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identity.#").HasValue("0"),
 			),
 		},
 		data.ImportStep(),
@@ -150,6 +230,119 @@ func TestAccMonitorDataCollectionRule_complete(t *testing.T) {
 	})
 }
 
+func TestAccMonitorDataCollectionRule_descriptionAndTagsSetOutsideOfTerraform(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.setDescriptionAndTags, data.ResourceName),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			// confirm the values set outside of Terraform are read back, so the matching config is a no-op
+			Config:   r.descriptionAndTags(data),
+			PlanOnly: true,
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorDataCollectionRule_iisAndWindowsFirewallLogs(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.iisAndWindowsFirewallLogs(data, "C:\\\\Logs\\\\W3SVC1"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_sources.0.iis_log.0.log_directories.#").HasValue("1"),
+				check.That(data.ResourceName).Key("data_sources.0.windows_firewall_log.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.iisAndWindowsFirewallLogs(data, "D:\\\\Logs\\\\W3SVC2"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorDataCollectionRule_vmInsights(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_data_collection_rule", "test")
+	r := MonitorDataCollectionRuleResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.vmInsights(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r MonitorDataCollectionRuleResource) setDescriptionAndTags(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := datacollectionrules.ParseDataCollectionRuleID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Monitor.DataCollectionRulesClient.Get(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	if resp.Model == nil || resp.Model.Properties == nil {
+		return fmt.Errorf("retrieving %s: model was nil", *id)
+	}
+
+	model := *resp.Model
+	model.Properties.Description = utils.String("created outside of terraform")
+	model.Tags = &map[string]string{
+		"ENV": "Test",
+	}
+
+	if _, err := client.Monitor.DataCollectionRulesClient.Create(ctx, *id, model); err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
+func (r MonitorDataCollectionRuleResource) descriptionAndTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  destinations {
+    azure_monitor_metrics {
+      name = "test-destination-metrics"
+    }
+  }
+  data_flow {
+    streams      = ["Microsoft-InsightsMetrics"]
+    destinations = ["test-destination-metrics"]
+  }
+  description = "created outside of terraform"
+  tags = {
+    ENV = "Test"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r MonitorDataCollectionRuleResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -263,6 +456,109 @@ resource "azurerm_monitor_data_collection_rule" "test" {
 `, r.template(data), data.RandomInteger, data.RandomString)
 }
 
+func (r MonitorDataCollectionRuleResource) directDestinationWithoutDirectToStoreKind(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  kind                = "Linux"
+  destinations {
+    event_hub_direct {
+      name         = "test-destination-eventhub-direct"
+      event_hub_id = "/subscriptions/${data.azurerm_client_config.current.subscription_id}/resourceGroups/${azurerm_resource_group.test.name}/providers/Microsoft.EventHub/namespaces/acceventn%[2]d/eventhubs/accevent%[2]d"
+    }
+  }
+
+  data_flow {
+    streams      = ["Microsoft-Syslog"]
+    destinations = ["test-destination-eventhub-direct"]
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MonitorDataCollectionRuleResource) windowsEventLogWithLinuxKind(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  kind                = "Linux"
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-log"
+    }
+  }
+
+  data_flow {
+    streams      = ["Microsoft-Event"]
+    destinations = ["test-destination-log"]
+  }
+
+  data_sources {
+    windows_event_log {
+      streams        = ["Microsoft-Event"]
+      x_path_queries = ["System!*[System[EventID=4648]]"]
+      name           = "test-datasource-wineventlog"
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MonitorDataCollectionRuleResource) dataImportWithoutEndpoint(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-log"
+    }
+  }
+
+  data_flow {
+    streams      = ["Custom-Table_CL"]
+    destinations = ["test-destination-log"]
+  }
+
+  data_sources {
+    data_import {
+      event_hub_data_source {
+        stream         = "Custom-Table_CL"
+        name           = "test-datasource-import-event"
+        consumer_group = "$Default"
+      }
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r MonitorDataCollectionRuleResource) systemAssigned(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -699,6 +995,57 @@ resource "azurerm_monitor_data_collection_rule" "test" {
 `, r.template(data), data.RandomInteger, data.RandomString)
 }
 
+func (r MonitorDataCollectionRuleResource) vmInsights(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  description         = "Data Collection Rule for VM Insights"
+
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "VMInsightsPerf-Logs-Dest"
+    }
+  }
+
+  data_flow {
+    streams      = ["Microsoft-InsightsMetrics"]
+    destinations = ["VMInsightsPerf-Logs-Dest"]
+  }
+
+  data_flow {
+    streams      = ["Microsoft-ServiceMap"]
+    destinations = ["VMInsightsPerf-Logs-Dest"]
+  }
+
+  data_sources {
+    performance_counter {
+      streams                       = ["Microsoft-InsightsMetrics"]
+      sampling_frequency_in_seconds = 60
+      counter_specifiers            = ["\\VmInsights\\DetailedMetrics"]
+      name                          = "VMInsightsPerfCounters"
+    }
+
+    extension {
+      streams        = ["Microsoft-ServiceMap"]
+      extension_name = "DependencyAgent"
+      name           = "DependencyAgentDataSource"
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r MonitorDataCollectionRuleResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -720,6 +1067,157 @@ resource "azurerm_monitor_data_collection_rule" "import" {
 `, r.basic(data))
 }
 
+func (r MonitorDataCollectionRuleResource) iisAndWindowsFirewallLogs(data acceptance.TestData, logDirectory string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-log"
+    }
+  }
+
+  data_flow {
+    streams      = ["Microsoft-W3CIISLog", "Microsoft-ASimNetworkSessionLogs-WindowsFirewall"]
+    destinations = ["test-destination-log"]
+  }
+
+  data_sources {
+    iis_log {
+      name            = "test-datasource-iis"
+      streams         = ["Microsoft-W3CIISLog"]
+      log_directories = ["%[3]s"]
+    }
+
+    windows_firewall_log {
+      name    = "test-datasource-wfw"
+      streams = ["Microsoft-ASimNetworkSessionLogs-WindowsFirewall"]
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, logDirectory)
+}
+
+func (r MonitorDataCollectionRuleResource) multipleDataFlowsSharingStream(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_log_analytics_workspace" "test2" {
+  name                = "acctest-law2-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  kind                = "Linux"
+
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-errors"
+    }
+
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test2.id
+      name                  = "test-destination-others"
+    }
+  }
+
+  data_flow {
+    streams       = ["Microsoft-Syslog"]
+    destinations  = ["test-destination-errors"]
+    output_stream = "Microsoft-Syslog"
+    transform_kql = "source | where SeverityLevel == \"err\""
+  }
+
+  data_flow {
+    streams       = ["Microsoft-Syslog"]
+    destinations  = ["test-destination-others"]
+    output_stream = "Microsoft-Syslog"
+    transform_kql = "source | where SeverityLevel != \"err\""
+  }
+
+  data_sources {
+    syslog {
+      facility_names = ["*"]
+      log_levels     = ["*"]
+      name           = "test-datasource-syslog"
+      streams        = ["Microsoft-Syslog"]
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MonitorDataCollectionRuleResource) conflictingDataFlows(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                = "acctestmdcr-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  kind                = "Linux"
+
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-log"
+    }
+  }
+
+  data_flow {
+    streams       = ["Microsoft-Syslog"]
+    destinations  = ["test-destination-log"]
+    transform_kql = "source | where SeverityLevel == \"err\""
+  }
+
+  data_flow {
+    streams       = ["Microsoft-Syslog"]
+    destinations  = ["test-destination-log"]
+    output_stream = "Microsoft-Syslog"
+    transform_kql = "source | where SeverityLevel == \"err\""
+  }
+
+  data_sources {
+    syslog {
+      facility_names = ["*"]
+      log_levels     = ["*"]
+      name           = "test-datasource-syslog"
+      streams        = ["Microsoft-Syslog"]
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r MonitorDataCollectionRuleResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
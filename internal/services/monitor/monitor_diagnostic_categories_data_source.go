@@ -66,7 +66,7 @@ func dataSourceMonitorDiagnosticCategories() *pluginsdk.Resource {
 }
 
 func dataSourceMonitorDiagnosticCategoriesRead(d *pluginsdk.ResourceData, meta interface{}) error {
-	categoriesClient := meta.(*clients.Client).Monitor.DiagnosticSettingsCategoryClient
+	client := meta.(*clients.Client).Monitor
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -79,17 +79,13 @@ func dataSourceMonitorDiagnosticCategoriesRead(d *pluginsdk.ResourceData, meta i
 	}
 
 	// then retrieve the possible Diagnostics Categories for this Resource
-	categories, err := categoriesClient.DiagnosticSettingsCategoryList(ctx, *resourceIdToList)
+	categories, err := client.DiagnosticSettingsCategoriesForResource(ctx, *resourceIdToList)
 	if err != nil {
 		return fmt.Errorf("retrieving Diagnostics Categories for Resource %q: %+v", actualResourceId, err)
 	}
 
-	if categories.Model == nil && categories.Model.Value == nil {
-		return fmt.Errorf("retrieving Diagnostics Categories for Resource %q: `categories.Value` was nil", actualResourceId)
-	}
-
 	d.SetId(actualResourceId.ID())
-	val := *categories.Model.Value
+	val := *categories
 
 	metrics := make([]string, 0)
 	logs := make([]string, 0)
@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	authRuleParse "github.com/hashicorp/go-azure-sdk/resource-manager/eventhub/2021-11-01/authorizationrulesnamespaces"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2021-05-01-preview/diagnosticsettings"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
@@ -17,8 +19,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	eventhubValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/eventhub/validate"
 	kustoParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/kusto/parse"
+	monitorClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -26,6 +30,8 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
+const monitorDiagnosticSettingResourceName = "azurerm_monitor_diagnostic_setting"
+
 func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 	resource := &pluginsdk.Resource{
 		Create: resourceMonitorDiagnosticSettingCreate,
@@ -45,6 +51,8 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorDiagnosticSettingCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -57,7 +65,7 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 				Type:         pluginsdk.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: azure.ValidateResourceID,
+				ValidateFunc: validate.MonitorDiagnosticSettingTargetResourceID,
 			},
 
 			"eventhub_name": {
@@ -124,9 +132,10 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 						},
 
 						"retention_policy": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							MaxItems: 1,
+							Type:             pluginsdk.TypeList,
+							Optional:         true,
+							MaxItems:         1,
+							DiffSuppressFunc: monitorDiagnosticSettingRetentionPolicyDiffSuppress,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
 									"enabled": {
@@ -165,9 +174,10 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 						},
 
 						"retention_policy": {
-							Type:     pluginsdk.TypeList,
-							Optional: true,
-							MaxItems: 1,
+							Type:             pluginsdk.TypeList,
+							Optional:         true,
+							MaxItems:         1,
+							DiffSuppressFunc: monitorDiagnosticSettingRetentionPolicyDiffSuppress,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
 									"enabled": {
@@ -215,9 +225,10 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 					},
 
 					"retention_policy": {
-						Type:     pluginsdk.TypeList,
-						Optional: true,
-						MaxItems: 1,
+						Type:             pluginsdk.TypeList,
+						Optional:         true,
+						MaxItems:         1,
+						DiffSuppressFunc: monitorDiagnosticSettingRetentionPolicyDiffSuppress,
 						Elem: &pluginsdk.Resource{
 							Schema: map[string]*pluginsdk.Schema{
 								"enabled": {
@@ -242,6 +253,60 @@ func resourceMonitorDiagnosticSetting() *pluginsdk.Resource {
 	return resource
 }
 
+// monitorDiagnosticSettingCustomizeDiff ensures at least one log or metric is enabled, since otherwise the API accepts
+// the Diagnostic Setting but then returns a 404 when it's retrieved. Any value which isn't known yet is assumed to be
+// enabled, so that this check can't fail for configurations built from e.g. `dynamic` blocks. It also rejects `metric`
+// blocks for Subscription scoped settings, since the Activity Log has no metrics to export, and validates the categories
+// against those supported by the target resource (see validateMonitorDiagnosticSettingCategories).
+func monitorDiagnosticSettingCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if err := validateMonitorDiagnosticSettingCategories(ctx, d, meta.(*clients.Client).Monitor); err != nil {
+		return err
+	}
+
+	config := d.GetRawConfig()
+
+	if targetResourceId := config.GetAttr("target_resource_id"); targetResourceId.IsKnown() && !targetResourceId.IsNull() {
+		if _, err := commonids.ParseSubscriptionIDInsensitively(targetResourceId.AsString()); err == nil {
+			if metrics := config.GetAttr("metric"); metrics.IsKnown() && !metrics.IsNull() && metrics.LengthInt() > 0 {
+				return fmt.Errorf("`metric` is not supported when `target_resource_id` is a Subscription, since the Activity Log has no metrics")
+			}
+		}
+	}
+
+	if enabledLogs := config.GetAttr("enabled_log"); !enabledLogs.IsKnown() || (!enabledLogs.IsNull() && enabledLogs.LengthInt() > 0) {
+		return nil
+	}
+
+	keys := []string{"metric"}
+	if !features.FourPointOhBeta() {
+		keys = append(keys, "log")
+	}
+	for _, key := range keys {
+		blocks := config.GetAttr(key)
+		if !blocks.IsKnown() {
+			return nil
+		}
+		if blocks.IsNull() {
+			continue
+		}
+
+		for it := blocks.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() {
+				return nil
+			}
+
+			// `enabled` defaults to `true` when omitted
+			enabled := block.GetAttr("enabled")
+			if !enabled.IsKnown() || enabled.IsNull() || enabled.True() {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("at least one type of Log or Metric must be enabled")
+}
+
 func resourceMonitorDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.DiagnosticSettingsClient
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
@@ -251,6 +316,12 @@ func resourceMonitorDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta inte
 	id := diagnosticsettings.NewScopedDiagnosticSettingID(d.Get("target_resource_id").(string), d.Get("name").(string))
 	resourceId := fmt.Sprintf("%s|%s", id.ResourceUri, id.DiagnosticSettingName)
 
+	// the Insights RP intermittently fails concurrent writes to Diagnostic Settings for the same target resource, so
+	// these are serialized per target. Throttling across targets (429's) is retried by the base SDK client, which
+	// honours the `Retry-After` header, so the provider doesn't rate limit these writes itself
+	locks.ByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+	defer locks.UnlockByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+
 	existing, err := client.Get(ctx, id)
 	if err != nil {
 		if !response.WasNotFound(existing.HttpResponse) {
@@ -337,7 +408,7 @@ func resourceMonitorDiagnosticSettingCreate(d *pluginsdk.ResourceData, meta inte
 	}
 
 	if _, err := client.CreateOrUpdate(ctx, id, parameters); err != nil {
-		return fmt.Errorf("creating Monitor Diagnostics Setting %q for Resource %q: %+v", id.DiagnosticSettingName, id.ResourceUri, err)
+		return fmt.Errorf("creating Monitor Diagnostics Setting %q for Resource %q: %+v%s", id.DiagnosticSettingName, id.ResourceUri, err, monitorDiagnosticSettingRegionHint(d, err))
 	}
 
 	d.SetId(resourceId)
@@ -356,6 +427,9 @@ func resourceMonitorDiagnosticSettingUpdate(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
+	locks.ByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+	defer locks.UnlockByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+
 	existing, err := client.Get(ctx, *id)
 	if err != nil {
 		return fmt.Errorf("retrieving Monitor Diagnostics Setting %q for Resource %q: %+v", id.DiagnosticSettingName, id.ResourceUri, err)
@@ -448,7 +522,7 @@ func resourceMonitorDiagnosticSettingUpdate(d *pluginsdk.ResourceData, meta inte
 	}
 
 	if _, err := client.CreateOrUpdate(ctx, *id, parameters); err != nil {
-		return fmt.Errorf("updating Monitor Diagnostics Setting %q for Resource %q: %+v", id.DiagnosticSettingName, id.ResourceUri, err)
+		return fmt.Errorf("updating Monitor Diagnostics Setting %q for Resource %q: %+v%s", id.DiagnosticSettingName, id.ResourceUri, err, monitorDiagnosticSettingRegionHint(d, err))
 	}
 	return resourceMonitorDiagnosticSettingRead(d, meta)
 }
@@ -529,18 +603,29 @@ func resourceMonitorDiagnosticSettingRead(d *pluginsdk.ResourceData, meta interf
 			}
 			d.Set("log_analytics_destination_type", logAnalyticsDestinationType)
 
+			existingEnabledLogs := d.Get("enabled_log").(*pluginsdk.Set).List()
 			enabledLogs := flattenMonitorDiagnosticEnabledLogs(resp.Model.Properties.Logs)
+			enabledLogs = normalizeMonitorDiagnosticCategories(existingEnabledLogs, removeMonitorDiagnosticGroupedCategories(existingEnabledLogs, enabledLogs))
 			if err = d.Set("enabled_log", enabledLogs); err != nil {
 				return fmt.Errorf("setting `enabled_log`: %+v", err)
 			}
 
 			if !features.FourPointOhBeta() {
-				if err = d.Set("log", flattenMonitorDiagnosticLogs(resp.Model.Properties.Logs)); err != nil {
+				existingLogs := d.Get("log").(*pluginsdk.Set).List()
+				logs := flattenMonitorDiagnosticLogs(resp.Model.Properties.Logs)
+				logs = normalizeMonitorDiagnosticCategories(existingLogs, removeMonitorDiagnosticGroupedCategories(existingLogs, logs))
+				if err = d.Set("log", logs); err != nil {
 					return fmt.Errorf("setting `log`: %+v", err)
 				}
 			}
 
-			if err := d.Set("metric", flattenMonitorDiagnosticMetrics(resp.Model.Properties.Metrics)); err != nil {
+			// the Activity Log has no metrics, but the API can still return the (empty) metric categories
+			// for a Subscription scoped setting - which would otherwise show a diff
+			metrics := make([]interface{}, 0)
+			if _, err := commonids.ParseSubscriptionIDInsensitively(id.ResourceUri); err != nil {
+				metrics = normalizeMonitorDiagnosticCategories(d.Get("metric").(*pluginsdk.Set).List(), flattenMonitorDiagnosticMetrics(resp.Model.Properties.Metrics))
+			}
+			if err := d.Set("metric", metrics); err != nil {
 				return fmt.Errorf("setting `metric`: %+v", err)
 			}
 		}
@@ -559,6 +644,9 @@ func resourceMonitorDiagnosticSettingDelete(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
+	locks.ByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+	defer locks.UnlockByName(id.ResourceUri, monitorDiagnosticSettingResourceName)
+
 	resp, err := client.Delete(ctx, *id)
 	if err != nil {
 		if !response.WasNotFound(resp.HttpResponse) {
@@ -584,6 +672,125 @@ func resourceMonitorDiagnosticSettingDelete(d *pluginsdk.ResourceData, meta inte
 	return nil
 }
 
+// monitorDiagnosticSettingRegionHint returns additional context for errors returned by the API when a destination is in
+// a different region to the target resource. The Insights RP only requires the Storage Account and Event Hub Namespace
+// to be in the same region as the target resource (Log Analytics Workspaces can be in any region), but the error it
+// returns doesn't say which destination is at fault.
+func monitorDiagnosticSettingRegionHint(d *pluginsdk.ResourceData, err error) string {
+	message := strings.ToLower(err.Error())
+	if !strings.Contains(message, "region") && !strings.Contains(message, "location") {
+		return ""
+	}
+
+	destinations := make([]string, 0)
+	if v := d.Get("storage_account_id").(string); v != "" {
+		destinations = append(destinations, fmt.Sprintf("the Storage Account %q", v))
+	}
+	if v := d.Get("eventhub_authorization_rule_id").(string); v != "" {
+		destinations = append(destinations, fmt.Sprintf("the Event Hub Namespace of %q", v))
+	}
+	if len(destinations) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nThis may be because %s is not in the same region as the target resource - Diagnostic Settings require the Storage Account and Event Hub Namespace to be in the same region as the resource being monitored", strings.Join(destinations, " and/or "))
+}
+
+// validateMonitorDiagnosticSettingCategories ensures each configured log or metric category (or category group) is
+// listed by the Diagnostic Settings Categories API for the target resource, since a misspelt category otherwise
+// surfaces as a 404 once the Diagnostic Setting has been created. This is only done when the categories or the target
+// resource have changed, and is skipped when the target resource isn't known yet or its categories can't be listed.
+// The API doesn't list every category the Insights RP accepts (e.g. `AzureDiagnostics`), so prior to 4.0 unknown
+// categories are only logged as a warning to avoid breaking existing configurations.
+func validateMonitorDiagnosticSettingCategories(ctx context.Context, d *pluginsdk.ResourceDiff, client *monitorClient.Client) error {
+	keys := []string{"enabled_log", "metric"}
+	if !d.HasChange("target_resource_id") && !d.HasChanges(keys...) {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	targetResourceId := config.GetAttr("target_resource_id")
+	if !targetResourceId.IsKnown() || targetResourceId.IsNull() {
+		return nil
+	}
+	resourceUri := targetResourceId.AsString()
+
+	// Subscription scoped settings export the Activity Log, whose categories aren't listed by this API
+	if _, err := commonids.ParseSubscriptionIDInsensitively(resourceUri); err == nil {
+		return nil
+	}
+
+	configured := make(map[string]string)
+	for _, key := range keys {
+		blocks := config.GetAttr(key)
+		if !blocks.IsKnown() || blocks.IsNull() {
+			continue
+		}
+
+		attributes := []string{"category"}
+		if key != "metric" {
+			attributes = append(attributes, "category_group")
+		}
+		for it := blocks.ElementIterator(); it.Next(); {
+			_, block := it.Element()
+			if !block.IsKnown() || block.IsNull() {
+				continue
+			}
+			for _, attribute := range attributes {
+				if value := block.GetAttr(attribute); value.IsKnown() && !value.IsNull() && value.AsString() != "" {
+					configured[value.AsString()] = fmt.Sprintf("%s.%s", key, attribute)
+				}
+			}
+		}
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	scopeId, err := commonids.ParseScopeID(strings.TrimPrefix(resourceUri, "/"))
+	if err != nil {
+		return nil
+	}
+
+	categories, err := client.DiagnosticSettingsCategoriesForResource(ctx, *scopeId)
+	if err != nil {
+		log.Printf("[WARN] skipping validation of the categories since the Diagnostic Settings Categories for Resource %q couldn't be listed: %+v", resourceUri, err)
+		return nil
+	}
+
+	known := make(map[string]struct{})
+	for _, v := range *categories {
+		if v.Name != nil {
+			known[strings.ToLower(*v.Name)] = struct{}{}
+		}
+		if v.Properties != nil && v.Properties.CategoryGroups != nil {
+			for _, group := range *v.Properties.CategoryGroups {
+				known[strings.ToLower(group)] = struct{}{}
+			}
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(configured))
+	for value := range configured {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		if _, ok := known[strings.ToLower(value)]; !ok {
+			err := fmt.Errorf("`%s` %q is not supported by the Resource %q - the `azurerm_monitor_diagnostic_categories` Data Source can be used to list the supported values", configured[value], value, resourceUri)
+			if features.FourPointOhBeta() {
+				return err
+			}
+			log.Printf("[WARN] %+v", err)
+		}
+	}
+
+	return nil
+}
+
 func monitorDiagnosticSettingDeletedRefreshFunc(ctx context.Context, client *diagnosticsettings.DiagnosticSettingsClient, targetResourceId diagnosticsettings.ScopedDiagnosticSettingId) pluginsdk.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		res, err := client.Get(ctx, targetResourceId)
@@ -834,47 +1041,124 @@ func ParseMonitorDiagnosticId(monitorId string) (*diagnosticsettings.ScopedDiagn
 	return &identifier, nil
 }
 
+// removeMonitorDiagnosticGroupedCategories removes the individual log categories which the API reports alongside a
+// `category_group` (e.g. `allLogs`) when they aren't tracked in the existing state/configuration. These are covered by
+// the category group, and are otherwise surfaced as a diff whenever Azure adds a new log category to the group.
+// When importing there's nothing to compare against, so the API response is used as-is.
+func removeMonitorDiagnosticGroupedCategories(existing []interface{}, flattened []interface{}) []interface{} {
+	if len(existing) == 0 {
+		return flattened
+	}
+
+	hasCategoryGroup := false
+	for _, raw := range flattened {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if group, ok := v["category_group"].(string); ok && group != "" {
+				hasCategoryGroup = true
+				break
+			}
+		}
+	}
+	if !hasCategoryGroup {
+		return flattened
+	}
+
+	known := make(map[string]struct{})
+	for _, raw := range existing {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if category, ok := v["category"].(string); ok && category != "" {
+				known[strings.ToLower(category)] = struct{}{}
+			}
+		}
+	}
+
+	results := make([]interface{}, 0)
+	for _, raw := range flattened {
+		if v, ok := raw.(map[string]interface{}); ok {
+			if category, ok := v["category"].(string); ok && category != "" {
+				if _, ok := known[strings.ToLower(category)]; !ok {
+					continue
+				}
+			}
+		}
+		results = append(results, raw)
+	}
+
+	return results
+}
+
+// normalizeMonitorDiagnosticCategories updates the `category` and `category_group` of each flattened item to match the
+// casing used in the existing state/configuration, since some Resource Providers (e.g. Key Vault and Data Factory) return
+// these with a different casing to the one which was sent.
+func normalizeMonitorDiagnosticCategories(existing []interface{}, flattened []interface{}) []interface{} {
+	for _, key := range []string{"category", "category_group"} {
+		known := make(map[string]string)
+		for _, raw := range existing {
+			if v, ok := raw.(map[string]interface{}); ok {
+				if value, ok := v[key].(string); ok && value != "" {
+					known[strings.ToLower(value)] = value
+				}
+			}
+		}
+
+		for _, raw := range flattened {
+			if v, ok := raw.(map[string]interface{}); ok {
+				if value, ok := v[key].(string); ok {
+					if normalized, ok := known[strings.ToLower(value)]; ok {
+						v[key] = normalized
+					}
+				}
+			}
+		}
+	}
+
+	return flattened
+}
+
+// monitorDiagnosticSettingRetentionPolicyDiffSuppress ignores changes to a `retention_policy` when the Diagnostic
+// Setting doesn't export to a Storage Account - retention only applies to Storage Account destinations, and the API
+// drops (or defaults) the retention policy of settings which only export to e.g. a Log Analytics Workspace, which would
+// otherwise show as a diff on every plan. This intentionally isn't used by `azurerm_monitor_aad_diagnostic_setting`,
+// where `retention_policy` is Required and is sent as configured regardless of the destination.
+func monitorDiagnosticSettingRetentionPolicyDiffSuppress(_, _, _ string, d *pluginsdk.ResourceData) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		storageAccountId := config.GetAttr("storage_account_id")
+		return storageAccountId.IsKnown() && storageAccountId.IsNull()
+	}
+
+	return d.Get("storage_account_id").(string) == ""
+}
+
+// resourceMonitorDiagnosticLogSettingHash identifies each log by its category (or category group) so that the set is
+// stable regardless of the order the API returns them in. The `retention_policy` is intentionally excluded from the
+// hash since the API populates it for categories where it wasn't configured - changes to it are still detected, as
+// a difference within the matching set item rather than a replacement of the item.
 func resourceMonitorDiagnosticLogSettingHash(input interface{}) int {
 	var buf bytes.Buffer
 	if rawData, ok := input.(map[string]interface{}); ok {
 		if category, ok := rawData["category"]; ok {
-			buf.WriteString(fmt.Sprintf("%s-", category.(string)))
+			buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(category.(string))))
 		}
 		if categoryGroup, ok := rawData["category_group"]; ok {
-			buf.WriteString(fmt.Sprintf("%s-", categoryGroup.(string)))
+			buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(categoryGroup.(string))))
 		}
 		if enabled, ok := rawData["enabled"]; ok {
 			buf.WriteString(fmt.Sprintf("%t-", enabled.(bool)))
 		}
-		if policy, ok := rawData["retention_policy"].(map[string]interface{}); ok {
-			if policyEnabled, ok := policy["enabled"]; ok {
-				buf.WriteString(fmt.Sprintf("%t-", policyEnabled.(bool)))
-			}
-			if days, ok := policy["days"]; ok {
-				buf.WriteString(fmt.Sprintf("%d-", days.(int)))
-			}
-		}
 	}
 	return pluginsdk.HashString(buf.String())
 }
 
+// resourceMonitorDiagnosticMetricsSettingHash excludes the `retention_policy` for the same reasons as the log hash.
 func resourceMonitorDiagnosticMetricsSettingHash(input interface{}) int {
 	var buf bytes.Buffer
 	if rawData, ok := input.(map[string]interface{}); ok {
 		if category, ok := rawData["category"]; ok {
-			buf.WriteString(fmt.Sprintf("%s-", category.(string)))
+			buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(category.(string))))
 		}
 		if enabled, ok := rawData["enabled"]; ok {
 			buf.WriteString(fmt.Sprintf("%t-", enabled.(bool)))
 		}
-		if policy, ok := rawData["retention_policy"].(map[string]interface{}); ok {
-			if policyEnabled, ok := policy["enabled"]; ok {
-				buf.WriteString(fmt.Sprintf("%t-", policyEnabled.(bool)))
-			}
-			if days, ok := policy["days"]; ok {
-				buf.WriteString(fmt.Sprintf("%d-", days.(int)))
-			}
-		}
 	}
 	return pluginsdk.HashString(buf.String())
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/date"
@@ -17,16 +19,25 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/applicationinsights/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
 func resourceMonitorMetricAlert() *pluginsdk.Resource {
+	// the order of the `dimension` blocks isn't meaningful, but changing these from a list to a set is a breaking change
+	// since it changes how they're referenced, as such this is only done in 4.0
+	dimensionType := pluginsdk.TypeList
+	if features.FourPointOhBeta() {
+		dimensionType = pluginsdk.TypeSet
+	}
+
 	return &pluginsdk.Resource{
 		Create: resourceMonitorMetricAlertCreateUpdate,
 		Read:   resourceMonitorMetricAlertRead,
@@ -43,6 +54,8 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 			0: migration.MetricAlertUpgradeV0ToV1{},
 		}),
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorMetricAlertCustomizeDiff),
+
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
@@ -117,7 +130,7 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 							}, false),
 						},
 						"dimension": {
-							Type:     pluginsdk.TypeList,
+							Type:     dimensionType,
 							Optional: true,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
@@ -202,7 +215,7 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 							}, false),
 						},
 						"dimension": {
-							Type:     pluginsdk.TypeList,
+							Type:     dimensionType,
 							Optional: true,
 							Elem: &pluginsdk.Resource{
 								Schema: map[string]*pluginsdk.Schema{
@@ -247,7 +260,8 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 								string(metricalerts.DynamicThresholdSensitivityLow),
 								string(metricalerts.DynamicThresholdSensitivityMedium),
 								string(metricalerts.DynamicThresholdSensitivityHigh),
-							}, false),
+							}, true),
+							DiffSuppressFunc: suppress.CaseDifference,
 						},
 
 						"evaluation_total_count": {
@@ -265,9 +279,10 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 						},
 
 						"ignore_data_before": {
-							Type:         pluginsdk.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.IsRFC3339Time,
+							Type:             pluginsdk.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppress.RFC3339Time,
 						},
 						"skip_metric_validation": {
 							Type:     pluginsdk.TypeBool,
@@ -333,8 +348,9 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 			},
 
 			"description": {
-				Type:     pluginsdk.TypeString,
-				Optional: true,
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 4096),
 			},
 
 			"enabled": {
@@ -384,6 +400,85 @@ func resourceMonitorMetricAlert() *pluginsdk.Resource {
 	}
 }
 
+// monitorMetricAlertWindowSizesForFrequency lists the `window_size` values accepted by the API for each `frequency`,
+// the window must be at least as long as the evaluation frequency.
+var monitorMetricAlertWindowSizesForFrequency = map[string][]string{
+	"PT1M":  {"PT1M", "PT5M", "PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT5M":  {"PT5M", "PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT15M": {"PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT30M": {"PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT1H":  {"PT1H", "PT6H", "PT12H", "P1D"},
+}
+
+func monitorMetricAlertCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("frequency") && d.NewValueKnown("window_size") {
+		frequency := d.Get("frequency").(string)
+		windowSize := d.Get("window_size").(string)
+		if windowSizes, ok := monitorMetricAlertWindowSizesForFrequency[frequency]; ok && !utils.SliceContainsValue(windowSizes, windowSize) {
+			return fmt.Errorf("`window_size` %q is not valid for a `frequency` of %q, possible values are: %s", windowSize, frequency, strings.Join(windowSizes, ", "))
+		}
+	}
+
+	if !d.NewValueKnown("scopes") {
+		return nil
+	}
+
+	// web test availability alerts are scoped to both the Web Test and the Application Insights component
+	if len(d.Get("application_insights_web_test_location_availability_criteria").([]interface{})) > 0 {
+		return nil
+	}
+
+	scopes := d.Get("scopes").(*pluginsdk.Set).List()
+	if len(scopes) <= 1 {
+		return nil
+	}
+
+	// the API rejects multi-resource alerts spanning different resource types with a fairly vague error, so check this upfront
+	resourceTypes := make(map[string]string)
+	for _, raw := range scopes {
+		scope, ok := raw.(string)
+		if !ok || scope == "" {
+			// not known until apply
+			return nil
+		}
+
+		if resourceType := monitorScopeResourceType(scope); resourceType != "" {
+			resourceTypes[strings.ToLower(resourceType)] = resourceType
+		}
+	}
+
+	if len(resourceTypes) > 1 {
+		types := make([]string, 0)
+		for _, v := range resourceTypes {
+			types = append(types, v)
+		}
+		sort.Strings(types)
+		return fmt.Errorf("all `scopes` must be of the same resource type when multiple scopes are specified, got %s", strings.Join(types, ", "))
+	}
+
+	if targetResourceType := d.Get("target_resource_type").(string); targetResourceType != "" && d.NewValueKnown("target_resource_type") {
+		for k, v := range resourceTypes {
+			if !strings.EqualFold(k, targetResourceType) {
+				return fmt.Errorf("`target_resource_type` (%s) must match the resource type of the `scopes` (%s)", targetResourceType, v)
+			}
+		}
+	}
+
+	// the API requires both the resource type and region of the scopes when there's more than one scope - since existing
+	// configurations may rely on these being omitted from the configuration (e.g. when set via `ignore_changes`), this is
+	// only checked from 4.0. The region of the scopes can't be derived from their IDs, so that's left to the API.
+	if features.FourPointOhBeta() {
+		rawConfig := d.GetRawConfig()
+		for _, key := range []string{"target_resource_type", "target_resource_location"} {
+			if rawConfig.GetAttr(key).IsNull() {
+				return fmt.Errorf("`%s` must be specified when multiple `scopes` are specified", key)
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceMonitorMetricAlertCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.MetricAlertsClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
@@ -432,6 +527,21 @@ func resourceMonitorMetricAlertCreateUpdate(d *pluginsdk.ResourceData, meta inte
 		}
 		_, isLegacy = existing.Model.Properties.Criteria.(metricalerts.MetricAlertSingleResourceMultipleMetricCriteria)
 
+		// when only `enabled` is changing, patch just that field so that the rest of the alert (and its state) is left as-is
+		if !d.HasChangesExcept("enabled") {
+			patch := metricalerts.MetricAlertResourcePatch{
+				Properties: &metricalerts.MetricAlertPropertiesPatch{
+					// `criteria` is always serialized, so the existing value needs to be sent back
+					Criteria: existing.Model.Properties.Criteria,
+					Enabled:  utils.Bool(enabled),
+				},
+			}
+			if _, err := client.Update(ctx, id, patch); err != nil {
+				return fmt.Errorf("updating `enabled` for Monitor %s: %+v", id, err)
+			}
+
+			return resourceMonitorMetricAlertRead(d, meta)
+		}
 	}
 
 	criteria, err := expandMonitorMetricAlertCriteria(d, isLegacy)
@@ -522,32 +632,28 @@ func resourceMonitorMetricAlertRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("setting `scopes`: %+v", err)
 		}
 
-		// Determine the correct criteria schema to set
-		var criteriaSchema string
-		switch c := props.Criteria.(type) {
-		case metricalerts.MetricAlertSingleResourceMultipleMetricCriteria:
-			criteriaSchema = "criteria"
-		case metricalerts.MetricAlertMultipleResourceMultipleMetricCriteria:
-			if c.AllOf == nil || len(*c.AllOf) == 0 {
-				return fmt.Errorf("nil or empty contained criteria of MultipleResourceMultipleMetricCriteria")
+		criteriaSchema, err := monitorMetricAlertCriteriaSchema(props.Criteria)
+		if err != nil {
+			return fmt.Errorf("reading the criteria of %s: %+v", *id, err)
+		}
+		if criteriaSchema == "" {
+			// the criteria are cleared so that the next plan shows a diff, rather than failing the refresh - which would
+			// also prevent planning the update to `scopes` needed to fix this
+			log.Printf("[WARN] %s has no criteria, which happens when the resources in `scopes` have been deleted - `scopes` should be updated to reference existing resources", *id)
+			for _, key := range []string{"criteria", "dynamic_criteria", "application_insights_web_test_location_availability_criteria"} {
+				if err := d.Set(key, []interface{}{}); err != nil {
+					return fmt.Errorf("setting `%s`: %+v", key, err)
+				}
 			}
-			// `MinItems` defined in schema guaranteed there is at least one element.
-			switch (*c.AllOf)[0].(type) {
-			case metricalerts.DynamicMetricCriteria:
-				criteriaSchema = "dynamic_criteria"
-			case metricalerts.MetricCriteria:
-				criteriaSchema = "criteria"
+		} else {
+			monitorMetricAlertCriteria := flattenMonitorMetricAlertCriteria(props.Criteria)
+			if !features.FourPointOhBeta() {
+				monitorMetricAlertCriteria = orderMonitorMetricAlertDimensions(d.Get(criteriaSchema).([]interface{}), monitorMetricAlertCriteria)
+			}
+			// lintignore:R001
+			if err := d.Set(criteriaSchema, monitorMetricAlertCriteria); err != nil {
+				return fmt.Errorf("failed setting `%s`: %+v", criteriaSchema, err)
 			}
-		case metricalerts.WebtestLocationAvailabilityCriteria:
-			criteriaSchema = "application_insights_web_test_location_availability_criteria"
-		default:
-			return fmt.Errorf("unknown criteria type")
-		}
-
-		monitorMetricAlertCriteria := flattenMonitorMetricAlertCriteria(props.Criteria)
-		// lintignore:R001
-		if err := d.Set(criteriaSchema, monitorMetricAlertCriteria); err != nil {
-			return fmt.Errorf("failed setting `%s`: %+v", criteriaSchema, err)
 		}
 
 		if err := d.Set("action", flattenMonitorMetricAlertAction(props.Actions)); err != nil {
@@ -564,6 +670,32 @@ func resourceMonitorMetricAlertRead(d *pluginsdk.ResourceData, meta interface{})
 	return nil
 }
 
+// monitorMetricAlertCriteriaSchema returns the schema key which the criteria returned from the API are flattened into.
+// The API can return an alert without any criteria once the resources in `scopes` have been deleted, in which case no
+// schema key is returned.
+func monitorMetricAlertCriteriaSchema(input metricalerts.MetricAlertCriteria) (string, error) {
+	switch c := input.(type) {
+	case metricalerts.MetricAlertSingleResourceMultipleMetricCriteria:
+		return "criteria", nil
+	case metricalerts.MetricAlertMultipleResourceMultipleMetricCriteria:
+		if c.AllOf == nil || len(*c.AllOf) == 0 {
+			return "", nil
+		}
+		switch (*c.AllOf)[0].(type) {
+		case metricalerts.DynamicMetricCriteria:
+			return "dynamic_criteria", nil
+		case metricalerts.MetricCriteria:
+			return "criteria", nil
+		default:
+			return "", fmt.Errorf("unsupported criteria type %T", (*c.AllOf)[0])
+		}
+	case metricalerts.WebtestLocationAvailabilityCriteria:
+		return "application_insights_web_test_location_availability_criteria", nil
+	default:
+		return "", fmt.Errorf("unsupported criteria type %T", input)
+	}
+}
+
 func resourceMonitorMetricAlertDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.MetricAlertsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
@@ -604,7 +736,7 @@ func expandMonitorMetricAlertSingleResourceMultiMetricCriteria(input []interface
 	criteria := make([]metricalerts.MultiMetricCriteria, 0)
 	for i, item := range input {
 		v := item.(map[string]interface{})
-		dimensions := expandMonitorMetricDimension(v["dimension"].([]interface{}))
+		dimensions := expandMonitorMetricDimension(v["dimension"])
 		criteria = append(criteria, metricalerts.MetricCriteria{
 			Name:                 fmt.Sprintf("Metric%d", i+1),
 			MetricNamespace:      utils.String(v["metric_namespace"].(string)),
@@ -625,7 +757,7 @@ func expandMonitorMetricAlertMultiResourceMultiMetricForStaticMetricCriteria(inp
 	criteria := make([]metricalerts.MultiMetricCriteria, 0)
 	for i, item := range input {
 		v := item.(map[string]interface{})
-		dimensions := expandMonitorMetricDimension(v["dimension"].([]interface{}))
+		dimensions := expandMonitorMetricDimension(v["dimension"])
 		criteria = append(criteria, metricalerts.MetricCriteria{
 			Name:                 fmt.Sprintf("Metric%d", i+1),
 			MetricNamespace:      utils.String(v["metric_namespace"].(string)),
@@ -646,7 +778,7 @@ func expandMonitorMetricAlertMultiResourceMultiMetricForDynamicMetricCriteria(in
 	criteria := make([]metricalerts.MultiMetricCriteria, 0)
 	for i, item := range input {
 		v := item.(map[string]interface{})
-		dimensions := expandMonitorMetricDimension(v["dimension"].([]interface{}))
+		dimensions := expandMonitorMetricDimension(v["dimension"])
 
 		dynamicMetricCriteria := metricalerts.DynamicMetricCriteria{
 			Name:             fmt.Sprintf("Metric%d", i+1),
@@ -655,7 +787,7 @@ func expandMonitorMetricAlertMultiResourceMultiMetricForDynamicMetricCriteria(in
 			TimeAggregation:  metricalerts.AggregationTypeEnum(v["aggregation"].(string)),
 			Dimensions:       &dimensions,
 			Operator:         metricalerts.DynamicThresholdOperator(v["operator"].(string)),
-			AlertSensitivity: metricalerts.DynamicThresholdSensitivity(v["alert_sensitivity"].(string)),
+			AlertSensitivity: normalizeMonitorMetricAlertDynamicThresholdSensitivity(v["alert_sensitivity"].(string)),
 			FailingPeriods: metricalerts.DynamicThresholdFailingPeriods{
 				NumberOfEvaluationPeriods: float64(v["evaluation_total_count"].(int)),
 				MinFailingPeriodsToAlert:  float64(v["evaluation_failure_count"].(int)),
@@ -689,9 +821,18 @@ func expandMonitorMetricAlertWebtestLocAvailCriteria(input []interface{}) metric
 	}
 }
 
-func expandMonitorMetricDimension(input []interface{}) []metricalerts.MetricDimension {
+func expandMonitorMetricDimension(input interface{}) []metricalerts.MetricDimension {
+	// `dimension` is a set from 4.0 onwards
+	var dimensions []interface{}
+	switch v := input.(type) {
+	case *pluginsdk.Set:
+		dimensions = v.List()
+	case []interface{}:
+		dimensions = v
+	}
+
 	result := make([]metricalerts.MetricDimension, 0)
-	for _, dimension := range input {
+	for _, dimension := range dimensions {
 		dVal := dimension.(map[string]interface{})
 		result = append(result, metricalerts.MetricDimension{
 			Name:     dVal["name"].(string),
@@ -740,41 +881,10 @@ func flattenMonitorMetricAlertSingleResourceMultiMetricCriteria(input *[]metrica
 	if input == nil || len(*input) == 0 {
 		return nil
 	}
-	criteria := (*input)[0].(metricalerts.MetricCriteria)
-	metricName := criteria.MetricName
-	metricNamespace := criteria.MetricNamespace
-	timeAggregation := criteria.TimeAggregation
-
-	dimResult := make([]map[string]interface{}, 0)
-	if criteria.Dimensions != nil {
-		for _, dimension := range *criteria.Dimensions {
-			dVal := make(map[string]interface{})
-			dVal["name"] = dimension.Name
-			dVal["operator"] = dimension.Operator
-			dVal["values"] = dimension.Values
-			dimResult = append(dimResult, dVal)
-		}
-	}
-
-	operator := string(criteria.Operator)
-	threshold := criteria.Threshold
-
-	var skipMetricValidation bool
-	if criteria.SkipMetricValidation != nil {
-		skipMetricValidation = *criteria.SkipMetricValidation
-	}
 
-	return []interface{}{
-		map[string]interface{}{
-			"metric_namespace":       metricNamespace,
-			"metric_name":            metricName,
-			"aggregation":            timeAggregation,
-			"dimension":              dimResult,
-			"operator":               operator,
-			"threshold":              threshold,
-			"skip_metric_validation": skipMetricValidation,
-		},
-	}
+	// the legacy single resource criteria can contain multiple static criteria (each with their own metric namespace)
+	// which are flattened in the same way as for the multiple resource criteria
+	return flattenMonitorMetricAlertMultiResourceMultiMetricCriteria(input)
 }
 
 func flattenMonitorMetricAlertMultiResourceMultiMetricCriteria(input *[]metricalerts.MultiMetricCriteria) []interface{} {
@@ -826,7 +936,7 @@ func flattenMonitorMetricAlertMultiResourceMultiMetricCriteria(input *[]metrical
 			}
 			// DynamicMetricCriteria specific properties
 			v["operator"] = string(criteria.Operator)
-			v["alert_sensitivity"] = string(criteria.AlertSensitivity)
+			v["alert_sensitivity"] = string(normalizeMonitorMetricAlertDynamicThresholdSensitivity(string(criteria.AlertSensitivity)))
 
 			v["evaluation_total_count"] = int(criteria.FailingPeriods.NumberOfEvaluationPeriods)
 			v["evaluation_failure_count"] = int(criteria.FailingPeriods.MinFailingPeriodsToAlert)
@@ -860,6 +970,65 @@ func flattenMonitorMetricAlertMultiResourceMultiMetricCriteria(input *[]metrical
 	return result
 }
 
+// orderMonitorMetricAlertDimensions sorts the `dimension` blocks of each criteria returned from the API into the order
+// they're defined in the existing state/configuration (matched on `name`), since the API doesn't guarantee the order and
+// `dimension` is only a set from 4.0 onwards. Dimensions which aren't known yet are appended in API order.
+func orderMonitorMetricAlertDimensions(existing []interface{}, flattened []interface{}) []interface{} {
+	for i := 0; i < len(flattened) && i < len(existing); i++ {
+		criteria, ok := flattened[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existingCriteria, ok := existing[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dimensions, ok := criteria["dimension"].([]map[string]interface{})
+		if !ok {
+			continue
+		}
+		existingDimensions, ok := existingCriteria["dimension"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		positions := make(map[string]int)
+		for j, raw := range existingDimensions {
+			if v, ok := raw.(map[string]interface{}); ok {
+				if name, ok := v["name"].(string); ok {
+					if _, exists := positions[strings.ToLower(name)]; !exists {
+						positions[strings.ToLower(name)] = j
+					}
+				}
+			}
+		}
+		position := func(input map[string]interface{}) int {
+			if name, ok := input["name"].(string); ok {
+				if j, ok := positions[strings.ToLower(name)]; ok {
+					return j
+				}
+			}
+			return len(existingDimensions)
+		}
+		sort.SliceStable(dimensions, func(a, b int) bool {
+			return position(dimensions[a]) < position(dimensions[b])
+		})
+	}
+
+	return flattened
+}
+
+// normalizeMonitorMetricAlertDynamicThresholdSensitivity returns the API casing of the sensitivity (e.g. `High`), since
+// it's accepted case-insensitively and alerts created outside of Terraform can return it in a different casing.
+func normalizeMonitorMetricAlertDynamicThresholdSensitivity(input string) metricalerts.DynamicThresholdSensitivity {
+	for _, v := range metricalerts.PossibleValuesForDynamicThresholdSensitivity() {
+		if strings.EqualFold(input, v) {
+			return metricalerts.DynamicThresholdSensitivity(v)
+		}
+	}
+	return metricalerts.DynamicThresholdSensitivity(input)
+}
+
 func flattenMonitorMetricAlertWebtestLocAvailCriteria(input *metricalerts.WebtestLocationAvailabilityCriteria) []interface{} {
 	if input == nil {
 		return nil
@@ -0,0 +1,235 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2018-03-01/metricalerts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestFlattenMonitorMetricAlertSingleResourceMultiMetricCriteria(t *testing.T) {
+	input := []metricalerts.MultiMetricCriteria{
+		metricalerts.MetricCriteria{
+			Name:            "Metric1",
+			MetricNamespace: utils.String("microsoft.insights/components"),
+			MetricName:      "requests/count",
+			TimeAggregation: metricalerts.AggregationTypeEnumCount,
+			Operator:        metricalerts.OperatorGreaterThan,
+			Threshold:       10,
+		},
+		metricalerts.MetricCriteria{
+			Name:                 "Metric2",
+			MetricNamespace:      utils.String("Azure.ApplicationInsights"),
+			MetricName:           "CustomMetric",
+			TimeAggregation:      metricalerts.AggregationTypeEnumAverage,
+			Operator:             metricalerts.OperatorLessThan,
+			Threshold:            5.5,
+			SkipMetricValidation: utils.Bool(true),
+		},
+	}
+
+	actual := flattenMonitorMetricAlertSingleResourceMultiMetricCriteria(&input)
+	if len(actual) != len(input) {
+		t.Fatalf("expected %d criteria but got %d", len(input), len(actual))
+	}
+
+	expected := []struct {
+		namespace            string
+		name                 string
+		skipMetricValidation bool
+	}{
+		{
+			namespace: "microsoft.insights/components",
+			name:      "requests/count",
+		},
+		{
+			namespace:            "Azure.ApplicationInsights",
+			name:                 "CustomMetric",
+			skipMetricValidation: true,
+		},
+	}
+	for i, v := range expected {
+		criteria := actual[i].(map[string]interface{})
+		if criteria["metric_namespace"] != v.namespace {
+			t.Fatalf("expected `metric_namespace` of criteria %d to be %q but got %v", i, v.namespace, criteria["metric_namespace"])
+		}
+		if criteria["metric_name"] != v.name {
+			t.Fatalf("expected `metric_name` of criteria %d to be %q but got %v", i, v.name, criteria["metric_name"])
+		}
+		if criteria["skip_metric_validation"] != v.skipMetricValidation {
+			t.Fatalf("expected `skip_metric_validation` of criteria %d to be %t but got %v", i, v.skipMetricValidation, criteria["skip_metric_validation"])
+		}
+	}
+}
+
+func TestFlattenMonitorMetricAlertMultiResourceMultiMetricCriteriaAlertSensitivity(t *testing.T) {
+	for _, v := range []struct {
+		input    metricalerts.DynamicThresholdSensitivity
+		expected string
+	}{
+		{
+			input:    metricalerts.DynamicThresholdSensitivityHigh,
+			expected: "High",
+		},
+		{
+			input:    "medium",
+			expected: "Medium",
+		},
+		{
+			input:    "LOW",
+			expected: "Low",
+		},
+	} {
+		input := []metricalerts.MultiMetricCriteria{
+			metricalerts.DynamicMetricCriteria{
+				Name:             "Metric1",
+				MetricNamespace:  utils.String("Microsoft.Storage/storageAccounts"),
+				MetricName:       "UsedCapacity",
+				TimeAggregation:  metricalerts.AggregationTypeEnumAverage,
+				Operator:         metricalerts.DynamicThresholdOperatorGreaterThan,
+				AlertSensitivity: v.input,
+				FailingPeriods: metricalerts.DynamicThresholdFailingPeriods{
+					NumberOfEvaluationPeriods: 4,
+					MinFailingPeriodsToAlert:  4,
+				},
+			},
+		}
+
+		actual := flattenMonitorMetricAlertMultiResourceMultiMetricCriteria(&input)
+		if len(actual) != 1 {
+			t.Fatalf("expected 1 criteria but got %d", len(actual))
+		}
+		if sensitivity := actual[0].(map[string]interface{})["alert_sensitivity"]; sensitivity != v.expected {
+			t.Fatalf("expected `alert_sensitivity` to be %q for %q but got %v", v.expected, v.input, sensitivity)
+		}
+	}
+}
+
+func TestMonitorMetricAlertCriteriaSchema(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    metricalerts.MetricAlertCriteria
+		Expected string
+		Error    bool
+	}{
+		{
+			Name:     "single resource",
+			Input:    metricalerts.MetricAlertSingleResourceMultipleMetricCriteria{},
+			Expected: "criteria",
+		},
+		{
+			Name: "multiple resource static",
+			Input: metricalerts.MetricAlertMultipleResourceMultipleMetricCriteria{
+				AllOf: &[]metricalerts.MultiMetricCriteria{
+					metricalerts.MetricCriteria{},
+				},
+			},
+			Expected: "criteria",
+		},
+		{
+			Name: "multiple resource dynamic",
+			Input: metricalerts.MetricAlertMultipleResourceMultipleMetricCriteria{
+				AllOf: &[]metricalerts.MultiMetricCriteria{
+					metricalerts.DynamicMetricCriteria{},
+				},
+			},
+			Expected: "dynamic_criteria",
+		},
+		{
+			Name:     "web test",
+			Input:    metricalerts.WebtestLocationAvailabilityCriteria{},
+			Expected: "application_insights_web_test_location_availability_criteria",
+		},
+		{
+			// returned once the resources in `scopes` have been deleted
+			Name:     "multiple resource without criteria",
+			Input:    metricalerts.MetricAlertMultipleResourceMultipleMetricCriteria{},
+			Expected: "",
+		},
+		{
+			Name:  "nil",
+			Input: nil,
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := monitorMetricAlertCriteriaSchema(tc.Input)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("expected an error but got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if actual != tc.Expected {
+				t.Fatalf("expected %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestOrderMonitorMetricAlertDimensions(t *testing.T) {
+	dimension := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":     name,
+			"operator": "Include",
+			"values":   []string{"*"},
+		}
+	}
+	existing := []interface{}{
+		map[string]interface{}{
+			"dimension": []interface{}{dimension("ApiName"), dimension("GeoType")},
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Existing []interface{}
+		Returned []map[string]interface{}
+		Expected []string
+	}{
+		{
+			Name:     "matches the existing order",
+			Existing: existing,
+			Returned: []map[string]interface{}{dimension("geotype"), dimension("ApiName")},
+			Expected: []string{"ApiName", "geotype"},
+		},
+		{
+			Name:     "unknown dimensions are appended",
+			Existing: existing,
+			Returned: []map[string]interface{}{dimension("Authentication"), dimension("GeoType"), dimension("ApiName")},
+			Expected: []string{"ApiName", "GeoType", "Authentication"},
+		},
+		{
+			Name:     "import",
+			Existing: []interface{}{},
+			Returned: []map[string]interface{}{dimension("GeoType"), dimension("ApiName")},
+			Expected: []string{"GeoType", "ApiName"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			flattened := []interface{}{
+				map[string]interface{}{
+					"dimension": tc.Returned,
+				},
+			}
+
+			actual := orderMonitorMetricAlertDimensions(tc.Existing, flattened)
+			dimensions := actual[0].(map[string]interface{})["dimension"].([]map[string]interface{})
+			if len(dimensions) != len(tc.Expected) {
+				t.Fatalf("expected %d dimensions but got %d", len(tc.Expected), len(dimensions))
+			}
+			for i, name := range tc.Expected {
+				if dimensions[i]["name"] != name {
+					t.Fatalf("expected dimension %d to be %q but got %q", i, name, dimensions[i]["name"])
+				}
+			}
+		})
+	}
+}
@@ -3,12 +3,14 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2018-03-01/metricalerts"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -24,12 +26,69 @@ func TestAccMonitorMetricAlert_basic(t *testing.T) {
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("criteria.0.skip_metric_validation").HasValue("false"),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
+func TestAccMonitorMetricAlert_customMetricNamespace(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.customMetricNamespace(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("criteria.0.skip_metric_validation").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorMetricAlert_markdownDescription(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.markdownDescription(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").HasValue("# Storage capacity\n\nTriggered when **UsedCapacity** is > 55.5 & rising.\n\n- check the [runbook](https://example.com/runbook?a=1&b=2)\n- escalate to <oncall>\n"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorMetricAlert_dimensionOrder(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	if !features.FourPointOhBeta() {
+		t.Skip("`dimension` is only a set from 4.0 onwards")
+	}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.dimensionOrder(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("criteria.0.dimension.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config:   r.dimensionOrder(data, true),
+			PlanOnly: true,
+		},
+	})
+}
+
 func TestAccMonitorMetricAlert_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
 	r := MonitorMetricAlertResource{}
@@ -78,6 +137,41 @@ func TestAccMonitorMetricAlert_complete(t *testing.T) {
 	})
 }
 
+func TestAccMonitorMetricAlert_toggleEnabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	// toggling only `enabled` mustn't change the criteria, scopes or actions of the alert
+	checks := func(enabled string) acceptance.TestCheckFunc {
+		return acceptance.ComposeTestCheckFunc(
+			check.That(data.ResourceName).ExistsInAzure(r),
+			check.That(data.ResourceName).Key("enabled").HasValue(enabled),
+			check.That(data.ResourceName).Key("scopes.#").HasValue("1"),
+			check.That(data.ResourceName).Key("criteria.#").HasValue("1"),
+			check.That(data.ResourceName).Key("criteria.0.dimension.#").HasValue("1"),
+			check.That(data.ResourceName).Key("action.#").HasValue("2"),
+		)
+	}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.enabled(data, true),
+			Check:  checks("true"),
+		},
+		data.ImportStep(),
+		{
+			Config: r.enabled(data, false),
+			Check:  checks("false"),
+		},
+		data.ImportStep(),
+		{
+			Config: r.enabled(data, true),
+			Check:  checks("true"),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorMetricAlert_dynamicCriteria(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
 	r := MonitorMetricAlertResource{}
@@ -85,6 +179,22 @@ func TestAccMonitorMetricAlert_dynamicCriteria(t *testing.T) {
 	data.ResourceTest(t, r, []acceptance.TestStep{
 		{
 			Config: r.dynamicCriteria(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("dynamic_criteria.0.skip_metric_validation").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorMetricAlert_dynamicCriteriaIgnoreDataBefore(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.dynamicCriteriaIgnoreDataBefore(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
@@ -148,6 +258,46 @@ func TestAccMonitorMetricAlert_multiScope(t *testing.T) {
 	})
 }
 
+func TestAccMonitorMetricAlert_multiScopeMixedResourceTypes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.multiScopeMixedResourceTypes(data),
+			ExpectError: regexp.MustCompile("all `scopes` must be of the same resource type"),
+		},
+	})
+}
+
+func TestAccMonitorMetricAlert_multiScopeWithoutTargetResource(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	if !features.FourPointOhBeta() {
+		t.Skip("`target_resource_type` and `target_resource_location` are only required for multiple `scopes` from 4.0 onwards")
+	}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.multiScopeWithoutTargetResource(data),
+			ExpectError: regexp.MustCompile("`target_resource_type` must be specified when multiple `scopes` are specified"),
+		},
+	})
+}
+
+func TestAccMonitorMetricAlert_windowSizeShorterThanFrequency(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
+	r := MonitorMetricAlertResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.windowSizeShorterThanFrequency(data),
+			ExpectError: regexp.MustCompile("`window_size` \"PT5M\" is not valid for a `frequency` of \"PT15M\", possible values are: PT15M, PT30M, PT1H, PT6H, PT12H, P1D"),
+		},
+	})
+}
+
 func TestAccMonitorMetricAlert_applicationInsightsWebTest(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_metric_alert", "test")
 	r := MonitorMetricAlertResource{}
@@ -221,6 +371,178 @@ resource "azurerm_monitor_metric_alert" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger)
 }
 
+func (MonitorMetricAlertResource) windowSizeShorterThanFrequency(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = [azurerm_storage_account.test.id]
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThan"
+    threshold        = 55.5
+  }
+
+  frequency   = "PT15M"
+  window_size = "PT5M"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomInteger)
+}
+
+func (MonitorMetricAlertResource) customMetricNamespace(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_application_insights" "test" {
+  name                = "acctestAppInsights-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  application_type    = "web"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = [azurerm_application_insights.test.id]
+
+  criteria {
+    metric_namespace       = "azure.applicationinsights"
+    metric_name            = "acctestCustomMetric"
+    aggregation            = "Average"
+    operator               = "GreaterThan"
+    threshold              = 10
+    skip_metric_validation = true
+  }
+
+  window_size = "PT1H"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (MonitorMetricAlertResource) markdownDescription(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = [azurerm_storage_account.test.id]
+  description         = <<EOT
+# Storage capacity
+
+Triggered when **UsedCapacity** is > 55.5 & rising.
+
+- check the [runbook](https://example.com/runbook?a=1&b=2)
+- escalate to <oncall>
+EOT
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThan"
+    threshold        = 55.5
+  }
+
+  window_size = "PT1H"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (MonitorMetricAlertResource) dimensionOrder(data acceptance.TestData, reversed bool) string {
+	dimensions := []string{`
+    dimension {
+      name     = "ApiName"
+      operator = "Include"
+      values   = ["*"]
+    }
+`, `
+    dimension {
+      name     = "GeoType"
+      operator = "Include"
+      values   = ["Primary"]
+    }
+`}
+	if reversed {
+		dimensions[0], dimensions[1] = dimensions[1], dimensions[0]
+	}
+
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = [azurerm_storage_account.test.id]
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "Transactions"
+    aggregation      = "Total"
+    operator         = "GreaterThan"
+    threshold        = 50
+%[4]s%[5]s  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, dimensions[0], dimensions[1])
+}
+
 func (r MonitorMetricAlertResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -286,6 +608,42 @@ resource "azurerm_monitor_metric_alert" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
+func (MonitorMetricAlertResource) dynamicCriteriaIgnoreDataBefore(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa1%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = [azurerm_storage_account.test.id]
+
+  dynamic_criteria {
+    metric_namespace   = "Microsoft.Storage/storageAccounts"
+    metric_name        = "Availability"
+    aggregation        = "Minimum"
+    operator           = "GreaterThan"
+    alert_sensitivity  = "High"
+    ignore_data_before = "2022-03-02T17:04:05+02:00"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (MonitorMetricAlertResource) multiCriteria(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -343,7 +701,11 @@ resource "azurerm_monitor_metric_alert" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString)
 }
 
-func (MonitorMetricAlertResource) complete(data acceptance.TestData) string {
+func (r MonitorMetricAlertResource) complete(data acceptance.TestData) string {
+	return r.enabled(data, true)
+}
+
+func (MonitorMetricAlertResource) enabled(data acceptance.TestData, enabled bool) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
   features {}
@@ -378,7 +740,7 @@ resource "azurerm_monitor_metric_alert" "test" {
   name                = "acctestMetricAlert-%[1]d"
   resource_group_name = azurerm_resource_group.test.name
   scopes              = [azurerm_storage_account.test.id]
-  enabled             = true
+  enabled             = %[4]t
   auto_mitigate       = false
   severity            = 4
   description         = "This is a complete metric alert acceptance."
@@ -421,7 +783,7 @@ resource "azurerm_monitor_metric_alert" "test" {
     "Foo.Bar"     = "Test tag"
   }
 }
-`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, enabled)
 }
 
 func (MonitorMetricAlertResource) multiVMTemplate(data acceptance.TestData, count int) string {
@@ -522,6 +884,64 @@ resource "azurerm_monitor_metric_alert" "test" {
 `, r.multiVMTemplate(data, count), data.RandomInteger, data.Locations.Primary)
 }
 
+func (r MonitorMetricAlertResource) multiScopeWithoutTargetResource(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes              = azurerm_linux_virtual_machine.test.*.id
+  dynamic_criteria {
+    metric_namespace = "Microsoft.Compute/virtualMachines"
+    metric_name      = "CPU Credits Consumed"
+    aggregation      = "Average"
+
+    operator               = "GreaterOrLessThan"
+    alert_sensitivity      = "Medium"
+    skip_metric_validation = true
+  }
+  window_size = "PT5M"
+  frequency   = "PT5M"
+}
+`, r.multiVMTemplate(data, 2), data.RandomInteger)
+}
+
+func (MonitorMetricAlertResource) multiScopeMixedResourceTypes(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  scopes = [
+    "/subscriptions/${data.azurerm_client_config.current.subscription_id}/resourceGroups/acctestRG-%[1]d/providers/Microsoft.Storage/storageAccounts/acctestsa%[3]s",
+    "/subscriptions/${data.azurerm_client_config.current.subscription_id}/resourceGroups/acctestRG-%[1]d/providers/Microsoft.Insights/components/acctestAppInsights-%[1]d",
+  ]
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThan"
+    threshold        = 55.5
+  }
+
+  target_resource_type     = "Microsoft.Storage/storageAccounts"
+  target_resource_location = "%[2]s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (MonitorMetricAlertResource) applicationInsightsWebTestTemplate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2019-10-17-preview/privatelinkscopedresources"
@@ -118,6 +119,7 @@ func resourceMonitorPrivateLinkScopedServiceRead(d *pluginsdk.ResourceData, meta
 
 	resp, err := client.Get(ctx, *id)
 	if err != nil {
+		// a 404 is returned both when the Scoped Resource and when the parent Private Link Scope has been removed
 		if response.WasNotFound(resp.HttpResponse) {
 			log.Printf("[INFO] %s does not exist - removing from state", *id)
 			d.SetId("")
@@ -126,15 +128,21 @@ func resourceMonitorPrivateLinkScopedServiceRead(d *pluginsdk.ResourceData, meta
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
 	}
 
+	// when the linked resource is deleted the Scoped Resource is still returned, but without a linked resource
+	linkedResourceId := ""
+	if model := resp.Model; model != nil && model.Properties != nil {
+		linkedResourceId = pointer.From(model.Properties.LinkedResourceId)
+	}
+	if linkedResourceId == "" {
+		log.Printf("[INFO] the linked resource for %s no longer exists - removing from state", *id)
+		d.SetId("")
+		return nil
+	}
+
 	d.Set("name", id.ScopedResourceName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 	d.Set("scope_name", id.PrivateLinkScopeName)
-
-	if model := resp.Model; model != nil {
-		if props := model.Properties; props != nil {
-			d.Set("linked_resource_id", props.LinkedResourceId)
-		}
-	}
+	d.Set("linked_resource_id", linkedResourceId)
 
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/applicationinsights/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -46,6 +47,43 @@ func TestAccMonitorPrivateLinkScopedService_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccMonitorPrivateLinkScopedService_disappears(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_private_link_scoped_service", "test")
+	r := MonitorPrivateLinkScopedServiceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		data.DisappearsStep(acceptance.DisappearsStepData{
+			Config:       r.basic,
+			TestResource: r,
+		}),
+	})
+}
+
+func TestAccMonitorPrivateLinkScopedService_linkedResourceDeleted(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_private_link_scoped_service", "test")
+	r := MonitorPrivateLinkScopedServiceResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			// the Scoped Resource is still returned without a linked resource, so it's removed from state and recreated
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.deleteLinkedResource, "azurerm_application_insights.test"),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("linked_resource_id").MatchesOtherKey(check.That("azurerm_application_insights.test").Key("id")),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorPrivateLinkScopedService_dataCollectionEndpoint(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_private_link_scoped_service", "test")
 	r := MonitorPrivateLinkScopedServiceResource{}
@@ -78,6 +116,32 @@ func (r MonitorPrivateLinkScopedServiceResource) Exists(ctx context.Context, cli
 	return utils.Bool(resp.Model != nil), nil
 }
 
+func (r MonitorPrivateLinkScopedServiceResource) Destroy(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := privatelinkscopedresources.ParseScopedResourceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Monitor.PrivateLinkScopedResourcesClient.DeleteThenPoll(ctx, *id); err != nil {
+		return nil, fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r MonitorPrivateLinkScopedServiceResource) deleteLinkedResource(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	id, err := parse.ComponentID(state.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.AppInsights.ComponentsClient.Delete(ctx, id.ResourceGroup, id.Name); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, err)
+	}
+
+	return nil
+}
+
 func (r MonitorPrivateLinkScopedServiceResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
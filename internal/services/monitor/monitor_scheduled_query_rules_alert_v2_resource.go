@@ -3,6 +3,7 @@ package monitor
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -70,7 +71,10 @@ type ScheduledQueryRulesAlertV2FailingPeriodsModel struct {
 
 type ScheduledQueryRulesAlertV2Resource struct{}
 
-var _ sdk.ResourceWithUpdate = ScheduledQueryRulesAlertV2Resource{}
+var (
+	_ sdk.ResourceWithUpdate        = ScheduledQueryRulesAlertV2Resource{}
+	_ sdk.ResourceWithCustomizeDiff = ScheduledQueryRulesAlertV2Resource{}
+)
 
 func (r ScheduledQueryRulesAlertV2Resource) ResourceType() string {
 	return "azurerm_monitor_scheduled_query_rules_alert_v2"
@@ -80,6 +84,136 @@ func (r ScheduledQueryRulesAlertV2Resource) ModelObject() interface{} {
 	return &ScheduledQueryRulesAlertV2Model{}
 }
 
+func (r ScheduledQueryRulesAlertV2Resource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			// the API only allows muting actions for stateless alerts, so these can't be combined
+			if diff.Get("mute_actions_after_alert_duration").(string) != "" && diff.Get("auto_mitigation_enabled").(bool) {
+				return fmt.Errorf("`mute_actions_after_alert_duration` can only be set when `auto_mitigation_enabled` is `false`, since muting actions isn't supported for automatically resolved (stateful) alerts")
+			}
+
+			if err := scheduledQueryRulesAlertV2InferTargetResourceTypes(diff); err != nil {
+				return err
+			}
+
+			// the raw config is used for the `criteria` checks since the values may not be known yet
+			if criteria := diff.GetRawConfig().GetAttr("criteria"); criteria.IsKnown() && !criteria.IsNull() {
+				i := 0
+				for it := criteria.ElementIterator(); it.Next(); i++ {
+					_, v := it.Element()
+					if !v.IsKnown() || v.IsNull() {
+						continue
+					}
+
+					// an alert can't require more failing periods than are evaluated
+					if failingPeriods := v.GetAttr("failing_periods"); failingPeriods.IsKnown() && !failingPeriods.IsNull() {
+						for fpIt := failingPeriods.ElementIterator(); fpIt.Next(); {
+							_, fp := fpIt.Element()
+							minimumRaw := fp.GetAttr("minimum_failing_periods_to_trigger_alert")
+							evaluationRaw := fp.GetAttr("number_of_evaluation_periods")
+							if !minimumRaw.IsKnown() || !evaluationRaw.IsKnown() {
+								continue
+							}
+
+							// both default to `1` when omitted
+							minimum, evaluation := int64(1), int64(1)
+							if !minimumRaw.IsNull() {
+								minimum, _ = minimumRaw.AsBigFloat().Int64()
+							}
+							if !evaluationRaw.IsNull() {
+								evaluation, _ = evaluationRaw.AsBigFloat().Int64()
+							}
+							if minimum > evaluation {
+								return fmt.Errorf("`criteria.%d.failing_periods.0.minimum_failing_periods_to_trigger_alert` must be less than or equal to `criteria.%d.failing_periods.0.number_of_evaluation_periods`", i, i)
+							}
+						}
+					}
+
+					aggregation := v.GetAttr("time_aggregation_method")
+
+					// a count can't be negative, so a negative threshold would mean the alert either always or never fires - since
+					// existing rules (e.g. heartbeat alerts using `GreaterThan` `-1`) rely on this, it's only rejected from 4.0
+					if threshold := v.GetAttr("threshold"); features.FourPointOhBeta() && threshold.IsKnown() && !threshold.IsNull() && threshold.AsBigFloat().Sign() < 0 {
+						if aggregation.IsKnown() && !aggregation.IsNull() && aggregation.AsString() == string(scheduledqueryrules.TimeAggregationCount) {
+							return fmt.Errorf("`criteria.%d.threshold` can't be negative when `criteria.%d.time_aggregation_method` is %q", i, i, aggregation.AsString())
+						}
+					}
+
+					// every aggregation other than `Count` operates on a measure column
+					if !aggregation.IsKnown() || aggregation.IsNull() || aggregation.AsString() == string(scheduledqueryrules.TimeAggregationCount) {
+						continue
+					}
+
+					if v.GetAttr("metric_measure_column").IsNull() {
+						return fmt.Errorf("`criteria.%d.metric_measure_column` must be specified when `criteria.%d.time_aggregation_method` is %q", i, i, aggregation.AsString())
+					}
+				}
+			}
+
+			return nil
+		},
+		Timeout: 5 * time.Minute,
+	}
+}
+
+// scheduledQueryRulesAlertV2InferTargetResourceTypes sets `target_resource_types` when it's omitted, to the resource type
+// of the scopes when these are all resources of the same type (so that the results are split per resource) and otherwise
+// to no resource types. This is only done when the rule is created or the `scopes` change, so that existing rules (which
+// may have been created without any resource types) don't show a diff.
+func scheduledQueryRulesAlertV2InferTargetResourceTypes(diff *pluginsdk.ResourceDiff) error {
+	if diff.Id() != "" && !diff.HasChange("scopes") {
+		return nil
+	}
+
+	config := diff.GetRawConfig()
+	if targetResourceTypes := config.GetAttr("target_resource_types"); !targetResourceTypes.IsKnown() || !targetResourceTypes.IsNull() {
+		return nil
+	}
+
+	scopes := config.GetAttr("scopes")
+	if !scopes.IsWhollyKnown() {
+		return diff.SetNewComputed("target_resource_types")
+	}
+
+	inferred := make([]interface{}, 0)
+	if !scopes.IsNull() && scopes.LengthInt() > 0 {
+		resourceType := ""
+		for it := scopes.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			scopeType := ""
+			if !v.IsNull() {
+				scopeType = monitorScopeResourceType(v.AsString())
+			}
+			if scopeType == "" || (resourceType != "" && !strings.EqualFold(resourceType, scopeType)) {
+				resourceType = ""
+				break
+			}
+			resourceType = scopeType
+		}
+		if resourceType != "" {
+			inferred = append(inferred, resourceType)
+		}
+	}
+
+	existing := diff.Get("target_resource_types").([]interface{})
+	if len(existing) == len(inferred) {
+		matches := true
+		for i := range inferred {
+			if !strings.EqualFold(existing[i].(string), inferred[i].(string)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return nil
+		}
+	}
+
+	return diff.SetNew("target_resource_types", inferred)
+}
+
 func (r ScheduledQueryRulesAlertV2Resource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
 	return scheduledqueryrules.ValidateScheduledQueryRuleID
 }
@@ -172,21 +306,26 @@ func (r ScheduledQueryRulesAlertV2Resource) Arguments() map[string]*pluginsdk.Sc
 						},
 					},
 
+					// the API defaults this to 1 failing period out of 1 evaluation period when omitted - since this is returned
+					// the block is Computed, meaning removing it keeps the existing values (as documented)
 					"failing_periods": {
 						Type:     pluginsdk.TypeList,
 						Optional: true,
+						Computed: true,
 						MaxItems: 1,
 						Elem: &pluginsdk.Resource{
 							Schema: map[string]*pluginsdk.Schema{
 								"minimum_failing_periods_to_trigger_alert": {
 									Type:         pluginsdk.TypeInt,
-									Required:     true,
+									Optional:     true,
+									Default:      1,
 									ValidateFunc: validation.IntBetween(1, 6),
 								},
 
 								"number_of_evaluation_periods": {
 									Type:         pluginsdk.TypeInt,
-									Required:     true,
+									Optional:     true,
+									Default:      1,
 									ValidateFunc: validation.IntBetween(1, 6),
 								},
 							},
@@ -376,6 +515,7 @@ func (r ScheduledQueryRulesAlertV2Resource) Arguments() map[string]*pluginsdk.Sc
 		"target_resource_types": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
+			Computed: true,
 			Elem: &pluginsdk.Schema{
 				Type:         pluginsdk.TypeString,
 				ValidateFunc: validation.StringIsNotEmpty,
@@ -496,6 +636,19 @@ func (r ScheduledQueryRulesAlertV2Resource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("decoding: %+v", err)
 			}
 
+			// a tags-only change is sent as a PATCH so the rule isn't re-submitted, which would otherwise reset
+			// its alert state and trigger a re-evaluation
+			if !metadata.ResourceData.HasChangesExcept("tags") {
+				patch := scheduledqueryrules.ScheduledQueryRuleResourcePatch{
+					Tags: &resourceModel.Tags,
+				}
+				if _, err := client.Update(ctx, *id, patch); err != nil {
+					return fmt.Errorf("updating tags for %s: %+v", *id, err)
+				}
+
+				return nil
+			}
+
 			resp, err := client.Get(ctx, *id)
 			if err != nil {
 				return fmt.Errorf("retrieving %s: %+v", *id, err)
@@ -675,7 +828,18 @@ func (r ScheduledQueryRulesAlertV2Resource) Read() sdk.ResourceFunc {
 			}
 
 			if properties.Scopes != nil {
-				state.Scopes = *properties.Scopes
+				// the API doesn't always return the scope with the casing it was sent in - since `scopes` is ForceNew
+				// we keep the existing value when it only differs by case, to avoid recreating the rule
+				existingScopes := metadata.ResourceData.Get("scopes").([]interface{})
+				scopes := *properties.Scopes
+				for i := range scopes {
+					if i < len(existingScopes) {
+						if existing, ok := existingScopes[i].(string); ok && strings.EqualFold(existing, scopes[i]) {
+							scopes[i] = existing
+						}
+					}
+				}
+				state.Scopes = scopes
 			}
 
 			if properties.Severity != nil {
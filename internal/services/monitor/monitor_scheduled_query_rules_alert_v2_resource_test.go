@@ -3,6 +3,7 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
@@ -24,6 +26,9 @@ func TestAccMonitorScheduledQueryRulesAlertV2_basic(t *testing.T) {
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("criteria.0.failing_periods.0.minimum_failing_periods_to_trigger_alert").HasValue("1"),
+				check.That(data.ResourceName).Key("criteria.0.failing_periods.0.number_of_evaluation_periods").HasValue("1"),
+				check.That(data.ResourceName).Key("target_resource_types.#").HasValue("1"),
 			),
 		},
 		data.ImportStep(),
@@ -58,6 +63,96 @@ func TestAccMonitorScheduledQueryRulesAlertV2_complete(t *testing.T) {
 	})
 }
 
+func TestAccMonitorScheduledQueryRulesAlertV2_muteActionsWithAutoMitigation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.muteActionsWithAutoMitigation(data),
+			ExpectError: regexp.MustCompile("`mute_actions_after_alert_duration` can only be set when `auto_mitigation_enabled` is `false`"),
+		},
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_aggregationWithoutMeasureColumn(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.aggregationWithoutMeasureColumn(data),
+			ExpectError: regexp.MustCompile("`criteria.0.metric_measure_column` must be specified when `criteria.0.time_aggregation_method` is \"Average\""),
+		},
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_negativeCountThreshold(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skip("negative thresholds are only rejected for `Count` from 4.0 onwards")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.negativeCountThreshold(data),
+			ExpectError: regexp.MustCompile("`criteria.0.threshold` can't be negative when `criteria.0.time_aggregation_method` is \"Count\""),
+		},
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_workspaceAlertsStorage(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.workspaceAlertsStorage(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("workspace_alerts_storage_enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("is_workspace_alerts_storage_configured").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_failingPeriodsExceedEvaluationPeriods(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.failingPeriodsExceedEvaluationPeriods(data),
+			ExpectError: regexp.MustCompile("`criteria.0.failing_periods.0.minimum_failing_periods_to_trigger_alert` must be less than or equal to"),
+		},
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_invalidDimensionOperator(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.dimensions(data, "Equals"),
+			ExpectError: regexp.MustCompile("expected criteria.0.dimension.1.operator to be one of"),
+		},
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_dimensionExclude(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.dimensions(data, "Exclude"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("criteria.0.dimension.1.operator").HasValue("Exclude"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccMonitorScheduledQueryRulesAlertV2_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
 	r := MonitorScheduledQueryRulesAlertV2Resource{}
@@ -86,6 +181,91 @@ func TestAccMonitorScheduledQueryRulesAlertV2_update(t *testing.T) {
 	})
 }
 
+func TestAccMonitorScheduledQueryRulesAlertV2_targetResourceTypesInferred(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("target_resource_types.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// the inferred resource type of the previous scope mustn't be kept
+			Config: r.resourceGroupScope(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("target_resource_types.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			// removing `target_resource_types` keeps the current value since the `scopes` are unchanged
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("target_resource_types.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_updateTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.tags(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.env").HasValue("first"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.tags(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.env").HasValue("second"),
+				check.That(data.ResourceName).Key("enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("criteria.0.operator").HasValue("Equal"),
+				check.That(data.ResourceName).Key("criteria.0.threshold").HasValue("5"),
+				check.That(data.ResourceName).Key("criteria.0.time_aggregation_method").HasValue("Count"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorScheduledQueryRulesAlertV2_scopeCasing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_alert_v2", "test")
+	r := MonitorScheduledQueryRulesAlertV2Resource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			// a scope which only differs by case from the one returned by the API mustn't recreate the rule
+			Config: r.scopeLowerCase(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:   r.scopeLowerCase(data),
+			PlanOnly: true,
+		},
+	})
+}
+
 func (r MonitorScheduledQueryRulesAlertV2Resource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := scheduledqueryrules.ParseScheduledQueryRuleID(state.ID)
 	if err != nil {
@@ -155,6 +335,62 @@ resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
 `, template, data.RandomInteger, data.Locations.Primary)
 }
 
+func (r MonitorScheduledQueryRulesAlertV2Resource) scopeLowerCase(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [lower(azurerm_application_insights.test.id)]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) tags(data acceptance.TestData, env string) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+
+  tags = {
+    env = "%s"
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary, env)
+}
+
 func (r MonitorScheduledQueryRulesAlertV2Resource) requiresImport(data acceptance.TestData) string {
 	config := r.basic(data)
 	return fmt.Sprintf(`
@@ -178,6 +414,208 @@ resource "azurerm_monitor_scheduled_query_rules_alert_v2" "import" {
 `, config, data.Locations.Primary)
 }
 
+func (r MonitorScheduledQueryRulesAlertV2Resource) muteActionsWithAutoMitigation(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+
+  auto_mitigation_enabled           = true
+  mute_actions_after_alert_duration = "PT10M"
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) aggregationWithoutMeasureColumn(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Average"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) negativeCountThreshold(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = -1.0
+    operator                = "LessThan"
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) failingPeriodsExceedEvaluationPeriods(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+
+    failing_periods {
+      minimum_failing_periods_to_trigger_alert = 3
+      number_of_evaluation_periods             = 2
+    }
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) workspaceAlertsStorage(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctest-law-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  sku                 = "PerGB2018"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[4]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_log_analytics_linked_storage_account" "test" {
+  data_source_type      = "Alerts"
+  resource_group_name   = azurerm_resource_group.test.name
+  workspace_resource_id = azurerm_log_analytics_workspace.test.id
+  storage_account_ids   = [azurerm_storage_account.test.id]
+}
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                             = "acctest-isqr-%[2]d"
+  resource_group_name              = azurerm_resource_group.test.name
+  location                         = "%[3]s"
+  evaluation_frequency             = "PT5M"
+  window_duration                  = "PT5M"
+  scopes                           = [azurerm_log_analytics_workspace.test.id]
+  severity                         = 3
+  workspace_alerts_storage_enabled = true
+
+  criteria {
+    query                   = <<-QUERY
+      Heartbeat
+	    | summarize AggregatedValue = count() by bin(TimeGenerated, 5m)
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+
+  depends_on = [azurerm_log_analytics_linked_storage_account.test]
+}
+`, template, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (r MonitorScheduledQueryRulesAlertV2Resource) dimensions(data acceptance.TestData, operator string) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                 = "acctest-isqr-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = "%s"
+  evaluation_frequency = "PT5M"
+  window_duration      = "PT5M"
+  scopes               = [azurerm_application_insights.test.id]
+  severity             = 3
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion, client_City
+	  QUERY
+    time_aggregation_method = "Maximum"
+    threshold               = 5.0
+    operator                = "GreaterThan"
+    metric_measure_column   = "CountByCountry"
+
+    dimension {
+      name     = "client_CountryOrRegion"
+      operator = "Include"
+      values   = ["*"]
+    }
+    dimension {
+      name     = "client_City"
+      operator = "%s"
+      values   = ["Redmond"]
+    }
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary, operator)
+}
+
 func (r MonitorScheduledQueryRulesAlertV2Resource) complete(data acceptance.TestData) string {
 	template := r.template(data)
 	return fmt.Sprintf(`
@@ -236,6 +674,33 @@ resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
 `, template, data.RandomInteger, data.Locations.Primary)
 }
 
+func (r MonitorScheduledQueryRulesAlertV2Resource) resourceGroupScope(data acceptance.TestData) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_scheduled_query_rules_alert_v2" "test" {
+  name                  = "acctest-isqr-%d"
+  resource_group_name   = azurerm_resource_group.test.name
+  location              = "%s"
+  evaluation_frequency  = "PT5M"
+  window_duration       = "PT5M"
+  scopes                = [azurerm_resource_group.test.id]
+  severity              = 3
+  skip_query_validation = true
+  criteria {
+    query                   = <<-QUERY
+      requests
+	    | summarize CountByCountry=count() by client_CountryOrRegion
+	  QUERY
+    time_aggregation_method = "Count"
+    threshold               = 5.0
+    operator                = "Equal"
+  }
+}
+`, template, data.RandomInteger, data.Locations.Primary)
+}
+
 func (r MonitorScheduledQueryRulesAlertV2Resource) update(data acceptance.TestData) string {
 	template := r.template(data)
 	return fmt.Sprintf(`
@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	commonValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/validate"
@@ -21,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/rickb777/date/period"
 )
 
 func resourceMonitorSmartDetectorAlertRule() *pluginsdk.Resource {
@@ -37,6 +40,8 @@ func resourceMonitorSmartDetectorAlertRule() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(monitorSmartDetectorAlertRuleCustomizeDiff),
+
 		SchemaVersion: 1,
 		StateUpgraders: pluginsdk.StateUpgrades(map[int]pluginsdk.StateUpgrade{
 			0: migration.SmartDetectorAlertRuleV0ToV1{},
@@ -153,6 +158,35 @@ func resourceMonitorSmartDetectorAlertRule() *pluginsdk.Resource {
 	}
 }
 
+// monitorSmartDetectorAlertRuleMinimumFrequency is the shortest `frequency` accepted by the API, which documents that
+// "the time granularity must be in minutes and minimum value is 5 minutes" for every `detector_type`.
+const monitorSmartDetectorAlertRuleMinimumFrequency = "PT5M"
+
+func monitorSmartDetectorAlertRuleCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("detector_type") || !d.NewValueKnown("frequency") {
+		return nil
+	}
+
+	frequency := d.Get("frequency").(string)
+	p, err := period.Parse(frequency)
+	if err != nil {
+		// this is caught by the schema validation
+		return nil
+	}
+
+	if p.DurationApprox() < period.MustParse(monitorSmartDetectorAlertRuleMinimumFrequency).DurationApprox() {
+		err := fmt.Errorf("`frequency` must be at least `%s` when `detector_type` is `%s`, got `%s`", monitorSmartDetectorAlertRuleMinimumFrequency, d.Get("detector_type").(string), frequency)
+
+		// existing rules may have been created with a shorter `frequency`, so this is only rejected from 4.0
+		if features.FourPointOhBeta() {
+			return err
+		}
+		log.Printf("[WARN] %+v", err)
+	}
+
+	return nil
+}
+
 func resourceMonitorSmartDetectorAlertRuleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Monitor.SmartDetectorAlertRulesClient
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
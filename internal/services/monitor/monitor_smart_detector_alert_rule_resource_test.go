@@ -3,11 +3,13 @@ package monitor_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -88,6 +90,22 @@ func TestAccMonitorSmartDetectorAlertRule_update(t *testing.T) {
 	})
 }
 
+func TestAccMonitorSmartDetectorAlertRule_frequencyBelowMinimum(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_smart_detector_alert_rule", "test")
+	r := MonitorSmartDetectorAlertRuleResource{}
+
+	if !features.FourPointOhBeta() {
+		t.Skip("a `frequency` below the minimum is only rejected from 4.0 onwards")
+	}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.frequencyBelowMinimum(data),
+			ExpectError: regexp.MustCompile("`frequency` must be at least `PT5M` when `detector_type` is `FailureAnomaliesDetector`"),
+		},
+	})
+}
+
 func (t MonitorSmartDetectorAlertRuleResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := parse.SmartDetectorAlertRuleID(state.ID)
 	if err != nil {
@@ -106,6 +124,25 @@ func (r MonitorSmartDetectorAlertRuleResource) basic(data acceptance.TestData) s
 	return fmt.Sprintf(`
 %s
 
+resource "azurerm_monitor_smart_detector_alert_rule" "test" {
+  name                = "acctestSDAR-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  severity            = "Sev0"
+  scope_resource_ids  = [azurerm_application_insights.test.id]
+  frequency           = "PT5M"
+  detector_type       = "FailureAnomaliesDetector"
+
+  action_group {
+    ids = [azurerm_monitor_action_group.test.id]
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r MonitorSmartDetectorAlertRuleResource) frequencyBelowMinimum(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
 resource "azurerm_monitor_smart_detector_alert_rule" "test" {
   name                = "acctestSDAR-%d"
   resource_group_name = azurerm_resource_group.test.name
@@ -149,7 +186,7 @@ resource "azurerm_monitor_smart_detector_alert_rule" "test" {
   resource_group_name = azurerm_resource_group.test.name
   severity            = "Sev0"
   scope_resource_ids  = [azurerm_application_insights.test.id]
-  frequency           = "PT1M"
+  frequency           = "PT5M"
   detector_type       = "FailureAnomaliesDetector"
 
   description = "acctest"
@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+func ActionGroupName(i interface{}, k string) (warning []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if len(v) < 1 || len(v) > 260 {
+		errors = append(errors, fmt.Errorf("%s must be between 1 and 260 characters in length, got %d", k, len(v)))
+		return
+	}
+
+	if strings.ContainsAny(v, `*<>%{}&:\?/#|`) || strings.IndexFunc(v, unicode.IsControl) != -1 {
+		errors = append(errors, fmt.Errorf("%s cannot contain the characters `*<>%%{}&:\\?/#|` or control characters, got %q", k, v))
+		return
+	}
+
+	if strings.HasSuffix(v, " ") || strings.HasSuffix(v, ".") {
+		errors = append(errors, fmt.Errorf("%s cannot end with a space or period, got %q", k, v))
+		return
+	}
+
+	return
+}
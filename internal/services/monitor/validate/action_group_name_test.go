@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestActionGroupName(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// basic example
+			input:    "example-actiongroup",
+			expected: true,
+		},
+		{
+			// spaces, periods, parentheses and underscores are allowed
+			input:    "Critical Alerts (v2.1)_prod",
+			expected: true,
+		},
+		{
+			// can't contain a forward slash
+			input:    "alerts/prod",
+			expected: false,
+		},
+		{
+			// can't contain a hash
+			input:    "alerts#1",
+			expected: false,
+		},
+		{
+			// can't contain a control character
+			input:    "alerts\tprod",
+			expected: false,
+		},
+		{
+			// can't end with a period
+			input:    "alerts.",
+			expected: false,
+		},
+		{
+			// can't end with a space
+			input:    "alerts ",
+			expected: false,
+		},
+		{
+			// 260 characters
+			input:    strings.Repeat("a", 260),
+			expected: true,
+		},
+		{
+			// 261 characters
+			input:    strings.Repeat("a", 261),
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := ActionGroupName(v.input, "name")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ActionGroupVoiceReceiverCountryCode validates the format of the country code of a voice receiver - which countries voice
+// notifications are available for changes over time, so this is left to the API.
+func ActionGroupVoiceReceiverCountryCode(i interface{}, k string) (warning []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if strings.HasPrefix(v, "+") {
+		errors = append(errors, fmt.Errorf("%s must be specified without the leading `+`, e.g. %q rather than %q", k, strings.TrimPrefix(v, "+"), v))
+		return
+	}
+
+	if !regexp.MustCompile(`^[1-9][0-9]{0,2}$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must be a country calling code of between 1 and 3 digits without leading zeros, got %q", k, v))
+	}
+
+	return
+}
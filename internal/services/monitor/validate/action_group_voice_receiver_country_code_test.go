@@ -0,0 +1,66 @@
+package validate
+
+import "testing"
+
+func TestActionGroupVoiceReceiverCountryCode(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			// United States
+			input:    "1",
+			expected: true,
+		},
+		{
+			// United Kingdom
+			input:    "44",
+			expected: true,
+		},
+		{
+			// three digit country code
+			input:    "353",
+			expected: true,
+		},
+		{
+			// leading plus
+			input:    "+1",
+			expected: false,
+		},
+		{
+			// leading zeros
+			input:    "0044",
+			expected: false,
+		},
+		{
+			// country code which isn't known to support voice notifications
+			input:    "380",
+			expected: true,
+		},
+		{
+			// too long
+			input:    "1234",
+			expected: false,
+		},
+		{
+			// not a country code
+			input:    "abc",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := ActionGroupVoiceReceiverCountryCode(v.input, "country_code")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package validate
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -152,7 +154,36 @@ func AlertProcessingRuleScheduleTimeZone() pluginsdk.SchemaValidateFunc {
 		"Yakutsk Standard Time",
 		"Yukon Standard Time",
 	}
-	return validation.StringInSlice(timeZones, false)
+
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		v, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+
+		for _, timeZone := range timeZones {
+			if v == timeZone {
+				return
+			}
+		}
+
+		// the full list of time zones is too long to be useful in the error, so point at the likely mistake instead
+		for _, timeZone := range timeZones {
+			if strings.EqualFold(v, timeZone) {
+				errors = append(errors, fmt.Errorf("%s must be a Windows time zone ID, which is case sensitive - did you mean %q? got %q", k, timeZone, v))
+				return
+			}
+		}
+
+		if strings.Contains(v, "/") {
+			errors = append(errors, fmt.Errorf("%s must be a Windows time zone ID (for example `W. Europe Standard Time`) rather than an IANA time zone name, got %q", k, v))
+			return
+		}
+
+		errors = append(errors, fmt.Errorf("%s must be a Windows time zone ID as returned by `[System.TimeZoneInfo]::GetSystemTimeZones()` (for example `UTC` or `Pacific Standard Time`), got %q", k, v))
+		return
+	}
 }
 
 func AlertProcessingRuleScheduleTime() pluginsdk.SchemaValidateFunc {
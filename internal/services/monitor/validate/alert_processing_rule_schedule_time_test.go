@@ -0,0 +1,52 @@
+package validate
+
+import "testing"
+
+func TestAlertProcessingRuleScheduleTimeZone(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected bool
+	}{
+		{
+			// empty
+			input:    "",
+			expected: false,
+		},
+		{
+			input:    "UTC",
+			expected: true,
+		},
+		{
+			input:    "Pacific Standard Time",
+			expected: true,
+		},
+		{
+			input:    "W. Europe Standard Time",
+			expected: true,
+		},
+		{
+			// time zone IDs are case sensitive
+			input:    "pacific standard time",
+			expected: false,
+		},
+		{
+			// IANA time zone names aren't supported
+			input:    "Europe/London",
+			expected: false,
+		},
+		{
+			input:    "Not A Time Zone",
+			expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.input)
+
+		_, errors := AlertProcessingRuleScheduleTimeZone()(v.input, "time_zone")
+		actual := len(errors) == 0
+		if v.expected != actual {
+			t.Fatalf("Expected %t but got %t", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2022-05-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+// storageAccountSubServiceTypes are the nested Storage Account services which support their own Diagnostic Settings
+var storageAccountSubServiceTypes = []string{
+	"blobServices",
+	"fileServices",
+	"queueServices",
+	"tableServices",
+}
+
+func MonitorDiagnosticSettingTargetResourceID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if warnings, errors = azure.ValidateResourceID(v, key); len(errors) > 0 {
+		return
+	}
+
+	// Diagnostic Settings for Storage are configured on the nested service (e.g. `{storageAccountId}/blobServices/default`)
+	// rather than on the Storage Account itself, so ensure these are well-formed when specified.
+	for _, serviceType := range storageAccountSubServiceTypes {
+		segment := fmt.Sprintf("/%s/", serviceType)
+		index := strings.Index(strings.ToLower(v), strings.ToLower(segment))
+		if index == -1 {
+			continue
+		}
+
+		storageAccountId := v[:index]
+		if _, errs := storageaccounts.ValidateStorageAccountID(storageAccountId, key); len(errs) > 0 {
+			errors = append(errors, fmt.Errorf("expected the %s in %q to be nested under a Storage Account ID, got %q", serviceType, key, storageAccountId))
+			return
+		}
+
+		if serviceName := v[index+len(segment):]; !strings.EqualFold(serviceName, "default") {
+			errors = append(errors, fmt.Errorf("expected the %s name in %q to be `default`, got %q", serviceType, key, serviceName))
+		}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package validate
+
+import "testing"
+
+func TestMonitorDiagnosticSettingTargetResourceID(t *testing.T) {
+	cases := []struct {
+		Input string
+		Valid bool
+	}{
+		{
+			// empty
+			Input: "",
+			Valid: false,
+		},
+		{
+			// subscription
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Valid: true,
+		},
+		{
+			// key vault
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1",
+			Valid: true,
+		},
+		{
+			// log analytics workspace
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1",
+			Valid: true,
+		},
+		{
+			// storage account
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			Valid: true,
+		},
+		{
+			// blob service
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/blobServices/default",
+			Valid: true,
+		},
+		{
+			// file service
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/fileServices/default",
+			Valid: true,
+		},
+		{
+			// queue service
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/queueServices/default",
+			Valid: true,
+		},
+		{
+			// table service
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/tableServices/default",
+			Valid: true,
+		},
+		{
+			// table service with different casing
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/TableServices/Default",
+			Valid: true,
+		},
+		{
+			// blob service with non-default name
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/blobServices/other",
+			Valid: false,
+		},
+		{
+			// blob service not nested under a storage account
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.KeyVault/vaults/vault1/blobServices/default",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %s", tc.Input)
+		_, errors := MonitorDiagnosticSettingTargetResourceID(tc.Input, "target_resource_id")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t", tc.Valid, valid)
+		}
+	}
+}